@@ -0,0 +1,114 @@
+// Package generate implements the generate subcommand of the provider binary.
+// It renders the terraform configuration to import the records of an existing zone,
+// read either from a BIND zone file or from AutoDNS.
+package generate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"terraform-provider-autodns/internal/api"
+	"terraform-provider-autodns/internal/provider"
+	"terraform-provider-autodns/internal/zonefile"
+)
+
+const usage = `Usage: terraform-provider-autodns generate -zone-id ZONE_ID [-file ZONE_FILE] [-out FILE]
+
+Generates autodns_record resources and import blocks for the records of a zone.
+The records are read from the BIND zone file, or from AutoDNS when no file is given.
+AutoDNS is reached like the provider configured with the AUTODNS_* environment
+variables only, e.g. AUTODNS_USERNAME, AUTODNS_PASSWORD and AUTODNS_CONTEXT.
+
+Options:
+`
+
+// Run runs the subcommand with the arguments following "generate" and returns the exit code.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	zoneIDFlag := flags.String("zone-id", "", "AutoDNS zone ID in the format zoneOrigin@zoneVirtualNameServer")
+	file := flags.String("file", "", "BIND zone file to read the records from, - reads it from stdin")
+	out := flags.String("out", "", "file to write the configuration to instead of stdout")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	zoneID, err := api.ParseZoneID(*zoneIDFlag)
+	if err != nil || flags.NArg() != 0 {
+		flags.Usage()
+		return 2
+	}
+
+	var records []api.Record
+	if *file != "" {
+		records, err = readZoneFile(*file, zoneID, stdin)
+	} else {
+		records, err = fetchRecords(ctx, zoneID)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	// The nameservers of the zone apex are managed by AutoDNS as part of the zone
+	records = slices.DeleteFunc(records, func(r api.Record) bool {
+		return r.Name == "" && r.Type == "NS"
+	})
+
+	config, err := provider.GenerateRecordConfig(ctx, zoneID, records)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if *out != "" {
+		err = os.WriteFile(*out, config, 0o644)
+	} else {
+		_, err = stdout.Write(config)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func readZoneFile(name string, zoneID api.ZoneID, stdin io.Reader) ([]api.Record, error) {
+	if name == "-" {
+		return zonefile.Parse(stdin, zoneID.Origin)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return zonefile.Parse(f, zoneID.Origin)
+}
+
+// fetchRecords reads the records of the zone from AutoDNS, configured like the provider via environment variables.
+func fetchRecords(ctx context.Context, zoneID api.ZoneID) ([]api.Record, error) {
+	client, err := provider.NewClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	if client.AuthMode == api.AuthModeSession {
+		defer client.Logout(ctx)
+	}
+
+	return client.GetRecords(ctx, zoneID)
+}
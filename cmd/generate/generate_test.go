@@ -0,0 +1,151 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"terraform-provider-autodns/internal/api/autodnstest"
+	"testing"
+)
+
+const testZoneFile = `$TTL 300
+@	IN	NS	a.ns14.net.
+@	IN	MX	20 mx2
+@	IN	MX	10 mx1
+www	IN	A	192.0.2.1
+www	IN	A	192.0.2.2
+txt	IN	TXT	"${not_a_template}"
+`
+
+const testConfig = `import {
+  to = autodns_record.apex_mx
+  id = "example.com@a.ns14.net____MX"
+}
+
+resource "autodns_record" "apex_mx" {
+  zone_id = "example.com@a.ns14.net"
+  name    = ""
+  type    = "MX"
+  ttl     = 300
+  values  = [
+    "20 mx2.example.com",
+    "10 mx1.example.com",
+  ]
+}
+
+import {
+  to = autodns_record.txt_txt
+  id = "example.com@a.ns14.net__txt__TXT"
+}
+
+resource "autodns_record" "txt_txt" {
+  zone_id = "example.com@a.ns14.net"
+  name    = "txt"
+  type    = "TXT"
+  ttl     = 300
+  values  = ["$${not_a_template}"]
+}
+
+import {
+  to = autodns_record.www_a
+  id = "example.com@a.ns14.net__www__A"
+}
+
+resource "autodns_record" "www_a" {
+  zone_id = "example.com@a.ns14.net"
+  name    = "www"
+  type    = "A"
+  ttl     = 300
+  values  = [
+    "192.0.2.1",
+    "192.0.2.2",
+  ]
+}
+`
+
+func TestRunZoneFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := Run(context.Background(), []string{"-zone-id", "example.com@a.ns14.net", "-file", "-"}, strings.NewReader(testZoneFile), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Run() = %d, stderr: %s", code, stderr.String())
+	}
+
+	if stdout.String() != testConfig {
+		t.Errorf("Run() wrote:\n%s\nwant:\n%s", stdout.String(), testConfig)
+	}
+}
+
+func TestRunLiveZone(t *testing.T) {
+	s := autodnstest.NewServer()
+	defer s.Close()
+
+	s.AddZone(api.Zone{
+		Origin:            "example.com",
+		VirtualNameServer: "a.ns14.net",
+		Records: []api.Record{
+			{Name: "_sip._tcp", Type: "SRV", TTL: 600, Value: "60 5060 sip.example.com", Pref: 10},
+		},
+	})
+
+	t.Setenv("AUTODNS_ENDPOINT", s.URL)
+	t.Setenv("AUTODNS_USERNAME", s.Username)
+	t.Setenv("AUTODNS_PASSWORD", s.Password)
+	// The context defaults to the one of the provider, the session is logged out afterwards
+	t.Setenv("AUTODNS_CONTEXT", "")
+	t.Setenv("AUTODNS_AUTH_MODE", api.AuthModeSession)
+	t.Setenv("AUTODNS_RETRY_WAIT_MIN", "1ms")
+	t.Setenv("AUTODNS_RETRY_WAIT_MAX", "1ms")
+
+	var stdout, stderr bytes.Buffer
+
+	code := Run(context.Background(), []string{"-zone-id", "example.com@a.ns14.net"}, nil, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Run() = %d, stderr: %s", code, stderr.String())
+	}
+
+	want := `resource "autodns_record" "_sip__tcp_srv" {
+  zone_id = "example.com@a.ns14.net"
+  name    = "_sip._tcp"
+  type    = "SRV"
+  ttl     = 600
+  values  = ["10 60 5060 sip.example.com"]
+}
+`
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("Run() wrote:\n%s\nwant it to contain:\n%s", stdout.String(), want)
+	}
+
+	if s.Sessions() != 0 {
+		t.Errorf("%d sessions are still logged in after Run()", s.Sessions())
+	}
+}
+
+func TestRunInvalidEnvironment(t *testing.T) {
+	t.Setenv("AUTODNS_USERNAME", "user")
+	t.Setenv("AUTODNS_PASSWORD", "secret")
+	t.Setenv("AUTODNS_AUTH_MODE", "token")
+
+	var stdout, stderr bytes.Buffer
+
+	if code := Run(context.Background(), []string{"-zone-id", "example.com@a.ns14.net"}, nil, &stdout, &stderr); code != 1 {
+		t.Errorf("Run() with an invalid auth mode = %d, want 1", code)
+	}
+
+	if !strings.Contains(stderr.String(), "Invalid AutoDNS Auth Mode") {
+		t.Errorf("Run() didn't report the invalid auth mode: %s", stderr.String())
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := Run(context.Background(), []string{"-zone-id", "example.com"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("Run() with an invalid zone ID = %d, want 2", code)
+	}
+
+	if !strings.Contains(stderr.String(), "Usage:") {
+		t.Errorf("Run() didn't print the usage: %s", stderr.String())
+	}
+}
@@ -0,0 +1,11 @@
+data "autodns_contact" "example" {
+  alias = "foobar-owner"
+}
+
+resource "autodns_domain" "example" {
+  name         = "foobar.test"
+  name_servers = ["a.ns14.net", "b.ns14.net"]
+
+  owner_contact = data.autodns_contact.example.id
+  admin_contact = data.autodns_contact.example.id
+}
@@ -0,0 +1,29 @@
+data "autodns_records" "mx" {
+  zone_id = "airup.dev@a.ns14.net"
+  name    = ""
+  type    = "MX"
+}
+
+# Copy the MX records of the zone into another zone
+resource "autodns_zone_records" "copy" {
+  zone_id = "airup.com@a.ns14.net"
+
+  exclude_types = ["NS", "SOA"]
+
+  records = [
+    for r in data.autodns_records.mx.records : {
+      name  = r.name
+      type  = r.type
+      ttl   = r.ttl
+      value = r.value
+      pref  = r.pref
+    }
+  ]
+}
+
+# Look up a verification record by its value
+data "autodns_records" "verification" {
+  zone_id = "airup.dev@a.ns14.net"
+  type    = "TXT"
+  value   = "google-site-verification=abc123"
+}
@@ -0,0 +1,17 @@
+# All domain redirects of the foobar.test domain
+data "autodns_redirects" "foobar" {
+  filter = [
+    {
+      key   = "domain"
+      value = "foobar.test"
+    },
+    {
+      key   = "type"
+      value = "DOMAIN"
+    },
+  ]
+}
+
+output "redirect_targets" {
+  value = { for r in data.autodns_redirects.foobar.redirects : r.source => r.target }
+}
@@ -0,0 +1,9 @@
+data "autodns_zone_file" "example" {
+  zone_id = "airup.dev@a.ns14.net"
+}
+
+# Keep a copy of the zone for auditing
+resource "local_file" "zone" {
+  filename = "${path.module}/airup.dev.zone"
+  content  = data.autodns_zone_file.example.content
+}
@@ -0,0 +1,51 @@
+# All .dev zones on the a.ns14.net virtual name server
+data "autodns_zones" "dev" {
+  filter = [
+    {
+      key   = "virtualNameServer"
+      value = "a.ns14.net"
+    },
+    {
+      key      = "origin"
+      value    = "*.dev"
+      operator = "LIKE"
+    },
+  ]
+}
+
+# Add a verification record to every one of them
+resource "autodns_record" "verification" {
+  for_each = { for z in data.autodns_zones.dev.zones : z.origin => z.id }
+
+  zone_id = each.value
+  name    = ""
+  type    = "TXT"
+  ttl     = 300
+  values  = ["google-site-verification=abc123"]
+}
+
+# Zones which are either .com or .net zones and have been updated this year
+data "autodns_zones" "recent" {
+  filter = [
+    {
+      filter = [
+        {
+          key      = "origin"
+          value    = "*.com"
+          operator = "LIKE"
+          link     = "OR"
+        },
+        {
+          key      = "origin"
+          value    = "*.net"
+          operator = "LIKE"
+        },
+      ]
+    },
+    {
+      key      = "updated"
+      value    = "2026-01-01T00:00:00.000+0000"
+      operator = "GREATER_EQUAL"
+    },
+  ]
+}
@@ -0,0 +1,21 @@
+resource "autodns_certificate" "example" {
+  zone_id     = "foobar.test@a.ns14.net"
+  common_name = "www.foobar.test"
+
+  subject_alternative_names = [
+    "foobar.test",
+  ]
+
+  # Renew the certificate 30 days before it expires
+  renewal_window = "720h"
+}
+
+# The generated key and the issued certificate, e.g. for a load balancer
+output "certificate" {
+  value = {
+    certificate = autodns_certificate.example.certificate_pem
+    chain       = autodns_certificate.example.chain_pem
+    private_key = autodns_certificate.example.private_key_pem
+  }
+  sensitive = true
+}
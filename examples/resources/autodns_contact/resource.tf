@@ -0,0 +1,19 @@
+resource "autodns_contact" "example" {
+  type         = "ORG"
+  alias        = "foobar-owner"
+  organization = "Foobar GmbH"
+
+  address     = ["Musterstraße 1"]
+  city        = "Berlin"
+  postal_code = "10115"
+  country     = "DE"
+  email       = "hostmaster@foobar.test"
+  phone       = "+49-30-1234567"
+
+  # Properties required by the registry of .it domains
+  extensions = {
+    it = {
+      entity_type = "2"
+    }
+  }
+}
@@ -0,0 +1,27 @@
+resource "autodns_domain" "example" {
+  name = "foobar.test"
+
+  name_servers = [
+    "a.ns14.net",
+    "b.ns14.net",
+    "c.ns14.net",
+    "d.ns14.net",
+  ]
+
+  auto_renew    = true
+  transfer_lock = true
+
+  # Publish the key signing keys of the signed zone at the registry
+  dnssec_keys = [
+    for key in autodns_zone_dnssec.example.dnskey_records : {
+      flags      = key.flags
+      protocol   = key.protocol
+      algorithm  = key.algorithm
+      public_key = key.public_key
+    } if key.flags == 257
+  ]
+}
+
+resource "autodns_zone_dnssec" "example" {
+  zone_id = "foobar.test@a.ns14.net"
+}
@@ -0,0 +1,5 @@
+resource "autodns_glue_host" "ns1" {
+  name           = "ns1.foobar.test"
+  ipv4_addresses = ["192.0.2.1"]
+  ipv6_addresses = ["2001:db8::1"]
+}
@@ -0,0 +1,23 @@
+# Vanity nameservers below foobar.test, registered with their glue records
+resource "autodns_glue_host" "vanity" {
+  for_each = {
+    ns1 = "192.0.2.1"
+    ns2 = "192.0.2.2"
+  }
+
+  name           = "${each.key}.foobar.test"
+  ipv4_addresses = [each.value]
+}
+
+resource "autodns_name_server_group" "vanity" {
+  name         = "foobar"
+  name_servers = [for host in autodns_glue_host.vanity : host.name]
+}
+
+# Zones of customers are served by the vanity nameservers
+resource "autodns_zone" "customer" {
+  origin              = "customer.test"
+  virtual_name_server = "a.ns14.net"
+  name_server_group   = autodns_name_server_group.vanity.name
+  name_servers        = autodns_name_server_group.vanity.name_servers
+}
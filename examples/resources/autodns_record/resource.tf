@@ -24,3 +24,32 @@ resource "autodns_record" "example_MX" {
   type   = "MX"
   values = ["10 foo", "20 bar"]
 }
+
+resource "autodns_record" "example_SRV" {
+  zone_id = "foobar.test@bar.ns.net"
+
+  name = "_sip._tcp"
+  ttl  = 60
+  type = "SRV"
+
+  srv {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.foobar.test"
+  }
+}
+
+resource "autodns_record" "example_CAA" {
+  zone_id = "foobar.test@bar.ns.net"
+
+  name = ""
+  ttl  = 60
+  type = "CAA"
+
+  caa {
+    flags = 0
+    tag   = "issue"
+    value = "letsencrypt.org"
+  }
+}
@@ -0,0 +1,14 @@
+# Permanently redirect the vanity domain to the campaign, keeping the path of the request
+resource "autodns_redirect" "promo" {
+  source        = "promo.foobar.test"
+  target        = "https://www.foobar.test/campaigns/spring"
+  forward_query = false
+}
+
+# Show the shop in a frame, the parked domain stays in the address bar
+resource "autodns_redirect" "shop" {
+  source        = "foobar-shop.test"
+  target        = "https://shop.foobar.test"
+  redirect_type = "frame"
+  title         = "Foobar Shop"
+}
@@ -0,0 +1,16 @@
+resource "autodns_zone" "example" {
+  origin              = "foobar.test"
+  virtual_name_server = "a.ns14.net"
+
+  name_servers = ["a.ns14.net", "b.ns14.net", "c.ns14.net", "d.ns14.net"]
+  main_ip      = "1.1.1.1"
+  www_include  = true
+
+  soa = {
+    refresh = 43200
+    retry   = 7200
+    expire  = 1209600
+    ttl     = 86400
+    email   = "hostmaster@foobar.test"
+  }
+}
@@ -0,0 +1,28 @@
+resource "autodns_zone_dnssec" "example" {
+  zone_id = "sub.foobar.test@a.ns14.net"
+
+  # Roll the keys over once a year
+  rollover_triggers = {
+    year = "2026"
+  }
+}
+
+# Delegate the signed subdomain from its parent zone
+resource "autodns_record" "example_DS" {
+  zone_id = "foobar.test@a.ns14.net"
+
+  name = "sub"
+  ttl  = 3600
+  type = "DS"
+
+  dynamic "ds" {
+    for_each = autodns_zone_dnssec.example.ds_records
+
+    content {
+      key_tag     = ds.value.key_tag
+      algorithm   = ds.value.algorithm
+      digest_type = ds.value.digest_type
+      digest      = ds.value.digest
+    }
+  }
+}
@@ -0,0 +1,26 @@
+resource "autodns_zone_records" "example" {
+  zone_id = "foobar.test@bar.ns.net"
+
+  exclude_types = ["NS", "SOA"]
+  exclude_names = ["^_acme-challenge"]
+
+  records = [
+    {
+      name  = ""
+      type  = "A"
+      value = "1.1.1.1"
+    },
+    {
+      name  = "www"
+      ttl   = 300
+      type  = "CNAME"
+      value = "foobar.test."
+    },
+    {
+      name  = ""
+      type  = "MX"
+      value = "mail.foobar.test."
+      pref  = 10
+    },
+  ]
+}
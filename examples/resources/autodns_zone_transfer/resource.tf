@@ -0,0 +1,16 @@
+# Serve the zone as secondary of an in-house primary nameserver
+resource "autodns_zone_transfer" "example" {
+  zone_id = "foobar.test@a.ns14.net"
+
+  zone_type   = "secondary"
+  primary_ips = ["192.0.2.53"]
+  notify_ips  = ["192.0.2.53"]
+}
+
+# Allow an external secondary nameserver to transfer a primary zone
+resource "autodns_zone_transfer" "backup" {
+  zone_id = "example.test@a.ns14.net"
+
+  allow_transfer_ips = ["198.51.100.53", "2001:db8::53"]
+  notify_ips         = ["198.51.100.53"]
+}
@@ -0,0 +1,349 @@
+package autodnstest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"time"
+)
+
+// DefaultCertificateValidity is how long the certificates issued by a new server are valid.
+const DefaultCertificateValidity = 365 * 24 * time.Hour
+
+// certificateAuthority signs the certificates issued by the server.
+type certificateAuthority struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	pem  string
+}
+
+// Certificate returns a copy of the certificate stored on the server.
+func (s *Server) Certificate(id int64) (*api.Certificate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	certificate, ok := s.certificates[id]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(certificate), true
+}
+
+func (s *Server) prepareCertificateOrder(w http.ResponseWriter, r *http.Request) {
+	certificate, ok := s.decodeCertificate(w, r)
+	if !ok {
+		return
+	}
+
+	s.writeData(w, "S0401", "Certificate order prepared successfully.", certificate)
+}
+
+func (s *Server) createCertificate(w http.ResponseWriter, r *http.Request) {
+	certificate, ok := s.decodeCertificate(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	certificate.ID = s.nextCertificateID()
+	certificate.Status = api.CertificateStatusPending
+	certificate.Created = now()
+	certificate.Updated = certificate.Created
+	s.certificates[certificate.ID] = certificate
+	certificate = deepCopy(certificate)
+	s.mu.Unlock()
+
+	s.writeData(w, "S0402", "Certificate ordered successfully.", certificate)
+}
+
+func (s *Server) renewCertificate(w http.ResponseWriter, r *http.Request) {
+	renewal, ok := s.decodeCertificate(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	id := certificateID(r)
+	certificate, ok := s.certificates[id]
+	if ok {
+		// The renewal keeps the ID, the certificate is pending until it's validated again
+		renewal.ID = id
+		renewal.Status = api.CertificateStatusPending
+		renewal.Created = certificate.Created
+		renewal.Updated = now()
+		s.certificates[id] = renewal
+		renewal = deepCopy(renewal)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeCertificateNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0403", "Certificate renewed successfully.", renewal)
+}
+
+func (s *Server) getCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	certificate, ok := s.certificates[certificateID(r)]
+	var err error
+	if ok {
+		// Pending certificates are issued as soon as the validation records exist
+		if certificate.Status == api.CertificateStatusPending && s.validated(certificate) {
+			err = s.issue(certificate)
+		}
+
+		certificate = deepCopy(certificate)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeCertificateNotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, "EF04000", "The certificate could not be issued.", api.Message{
+			Text:   err.Error(),
+			Status: "ERROR",
+		})
+		return
+	}
+
+	s.writeData(w, "S0405", "Certificate information inquired successfully.", certificate)
+}
+
+func (s *Server) deleteCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	id := certificateID(r)
+	_, ok := s.certificates[id]
+	delete(s.certificates, id)
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeCertificateNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0404", "Certificate deleted successfully.")
+}
+
+// decodeCertificate reads the certificate order of the request and adds the records validating its domains.
+func (s *Server) decodeCertificate(w http.ResponseWriter, r *http.Request) (*api.Certificate, bool) {
+	certificate := &api.Certificate{}
+	if !s.decode(w, r, certificate) {
+		return nil, false
+	}
+
+	domains, err := csrDomains(certificate.CSR)
+	if err == nil && !slices.Contains(domains, certificate.Name) {
+		err = errors.New("the common name of the CSR doesn't match the name of the certificate")
+	}
+
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "EF04020", "The certificate data is invalid.", api.Message{
+			Text:   err.Error(),
+			Code:   "EF04020",
+			Status: "ERROR",
+		})
+		return nil, false
+	}
+
+	certificate.Authentication = []api.DomainValidation{}
+	for _, domain := range domains {
+		token := sha256.Sum256([]byte(certificate.CSR + "|" + domain))
+		certificate.Authentication = append(certificate.Authentication, api.DomainValidation{
+			Domain: domain,
+			Name:   "_dnsauth." + strings.TrimPrefix(domain, "*."),
+			Type:   "TXT",
+			Value:  hex.EncodeToString(token[:16]),
+		})
+	}
+
+	certificate.ID = 0
+	certificate.Status = ""
+	certificate.Server = ""
+	certificate.Chain = nil
+	certificate.SerialNumber = ""
+	certificate.Expire = ""
+
+	return certificate, true
+}
+
+// validated reports whether all validation records of the certificate exist, the caller must hold the lock.
+func (s *Server) validated(certificate *api.Certificate) bool {
+	for _, v := range certificate.Authentication {
+		if !s.recordExists(v.Name, v.Type, v.Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordExists reports whether a zone of the server holds the record with the fully qualified name.
+func (s *Server) recordExists(fqdn, recordType, value string) bool {
+	for _, zone := range s.zones {
+		name, ok := strings.CutSuffix(fqdn, "."+zone.Origin)
+		if !ok {
+			if fqdn != zone.Origin {
+				continue
+			}
+			name = ""
+		}
+
+		if slices.ContainsFunc(zone.Records, func(r api.Record) bool {
+			return r.Name == name && r.Type == recordType && r.Value == value
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// issue signs the CSR of the certificate with the certificate authority of the server, the caller must hold the lock.
+func (s *Server) issue(certificate *api.Certificate) error {
+	if s.ca == nil {
+		ca, err := newCertificateAuthority()
+		if err != nil {
+			return err
+		}
+		s.ca = ca
+	}
+
+	block, _ := pem.Decode([]byte(certificate.CSR))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return err
+	}
+
+	issued := time.Now().UTC().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: certificate.Name},
+		DNSNames:     csr.DNSNames,
+		NotBefore:    issued,
+		NotAfter:     issued.Add(s.CertificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, s.ca.cert, csr.PublicKey, s.ca.key)
+	if err != nil {
+		return err
+	}
+
+	certificate.Status = api.CertificateStatusActive
+	certificate.Server = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	certificate.Chain = []string{s.ca.pem}
+	certificate.SerialNumber = strings.ToUpper(serial.Text(16))
+	certificate.Expire = template.NotAfter.Format("2006-01-02T15:04:05.000-0700")
+	certificate.Updated = now()
+
+	return nil
+}
+
+// nextCertificateID returns the ID for a new certificate, the caller must hold the lock.
+func (s *Server) nextCertificateID() int64 {
+	id := int64(1)
+	for existing := range s.certificates {
+		id = max(id, existing+1)
+	}
+
+	return id
+}
+
+func (s *Server) writeCertificateNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0405", "Certificate could not be found.", api.Message{
+		Text:    "The certificate does not exist.",
+		Code:    "EF04022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "certificate", Value: r.PathValue("id")}},
+	})
+}
+
+// certificateID returns the certificate ID of the request path, 0 when it's not a number.
+func certificateID(r *http.Request) int64 {
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	return id
+}
+
+// csrDomains returns the common name and the other DNS names of the PEM encoded CSR.
+func csrDomains(csrPEM string) ([]string, error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("the CSR is not a PEM encoded certificate request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+
+	domains := []string{csr.Subject.CommonName}
+	for _, name := range csr.DNSNames {
+		if !slices.Contains(domains, name) {
+			domains = append(domains, name)
+		}
+	}
+
+	return domains, nil
+}
+
+// newCertificateAuthority creates a self-signed certificate authority.
+func newCertificateAuthority() (*certificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "autodnstest CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificateAuthority{
+		key:  key,
+		cert: cert,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}, nil
+}
@@ -0,0 +1,285 @@
+package autodnstest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+)
+
+// AddContact stores the contact handle on the server and returns its ID.
+// Contacts without an ID get the next free one, like contacts created through the API.
+func (s *Server) AddContact(contact api.Contact) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := defaultContact(&contact)
+	if c.ID == 0 {
+		c.ID = s.nextContactID()
+	}
+	s.contacts[c.ID] = c
+
+	return c.ID
+}
+
+// Contact returns a copy of the contact handle stored on the server.
+func (s *Server) Contact(id int64) (*api.Contact, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	contact, ok := s.contacts[id]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(contact), true
+}
+
+func (s *Server) createContact(w http.ResponseWriter, r *http.Request) {
+	contact := &api.Contact{}
+	if !s.decode(w, r, contact) {
+		return
+	}
+
+	if msgs := validateContact(contact); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF03020", "The contact data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	contact = defaultContact(contact)
+	contact.ID = s.nextContactID()
+	contact.Created = now()
+	contact.Updated = contact.Created
+	s.contacts[contact.ID] = contact
+	contact = deepCopy(contact)
+	s.mu.Unlock()
+
+	s.writeData(w, "S0301", "Contact created successfully.", contact)
+}
+
+func (s *Server) searchContacts(w http.ResponseWriter, r *http.Request) {
+	zf := &api.SearchFilterReq{}
+	if !s.decode(w, r, zf) {
+		return
+	}
+
+	s.mu.Lock()
+	contacts := []any{}
+	for _, contact := range s.contacts {
+		if matchContact(contact, zf.Filters) {
+			contacts = append(contacts, deepCopy(contact))
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(contacts, func(a, b any) int {
+		return cmp.Compare(a.(*api.Contact).ID, b.(*api.Contact).ID)
+	})
+
+	// Searches without a view are truncated
+	view := api.View{Limit: s.SearchLimit}
+	if zf.View != nil {
+		view = *zf.View
+	}
+
+	total := len(contacts)
+	contacts = contacts[min(view.Offset, len(contacts)):]
+	contacts = contacts[:min(view.Limit, len(contacts))]
+
+	s.writeSearchData(w, "S0305", "Contacts searched successfully.", "Contact", total, contacts...)
+}
+
+func (s *Server) getContact(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	contact, ok := s.contacts[contactID(r)]
+	if ok {
+		contact = deepCopy(contact)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeContactNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0305", "Contact information inquired successfully.", contact)
+}
+
+func (s *Server) updateContact(w http.ResponseWriter, r *http.Request) {
+	contact := &api.Contact{}
+	if !s.decode(w, r, contact) {
+		return
+	}
+
+	if msgs := validateContact(contact); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF03020", "The contact data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	id := contactID(r)
+	stored, ok := s.contacts[id]
+	if ok {
+		// The contact is addressed by the path, the payload can't change its ID
+		contact.ID = id
+		contact.Created = stored.Created
+		contact.Updated = now()
+		contact = defaultContact(contact)
+		s.contacts[id] = contact
+		contact = deepCopy(contact)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeContactNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0302", "Contact updated successfully.", contact)
+}
+
+func (s *Server) deleteContact(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	id := contactID(r)
+	_, ok := s.contacts[id]
+	used := ok && s.contactUsed(id)
+	if ok && !used {
+		delete(s.contacts, id)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeContactNotFound(w, r)
+		return
+	}
+
+	if used {
+		s.writeError(w, http.StatusBadRequest, "EF03021", "The contact is still in use.", api.Message{
+			Text:    "The contact is assigned to a domain and can't be deleted.",
+			Code:    "EF03021",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "contact", Value: r.PathValue("id")}},
+		})
+		return
+	}
+
+	s.writeData(w, "S0303", "Contact deleted successfully.")
+}
+
+// contactUsed reports whether a domain references the contact, the caller must hold the lock.
+func (s *Server) contactUsed(id int64) bool {
+	for _, domain := range s.domains {
+		for _, ref := range []*api.ContactRef{domain.OwnerC, domain.AdminC, domain.TechC, domain.ZoneC} {
+			if ref != nil && ref.ID == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// nextContactID returns the ID for a new contact, the caller must hold the lock.
+func (s *Server) nextContactID() int64 {
+	id := int64(1)
+	for existing := range s.contacts {
+		id = max(id, existing+1)
+	}
+
+	return id
+}
+
+func (s *Server) writeContactNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0305", "Contact could not be found.", api.Message{
+		Text:    "The contact does not exist.",
+		Code:    "EF03022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "contact", Value: r.PathValue("id")}},
+	})
+}
+
+// contactID returns the contact ID of the request path, 0 when it's not a number.
+func contactID(r *http.Request) int64 {
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	return id
+}
+
+// matchContact reports whether the contact matches the filters.
+func matchContact(contact *api.Contact, filters []api.SearchFilter) bool {
+	return matchFilters(filters, func(key string) (string, bool) {
+		switch key {
+		case "id":
+			return strconv.FormatInt(contact.ID, 10), true
+		case "type":
+			return contact.Type, true
+		case "alias":
+			return contact.Alias, true
+		case "fname":
+			return contact.FirstName, true
+		case "lname":
+			return contact.LastName, true
+		case "organization":
+			return contact.Organization, true
+		case "email":
+			return contact.Email, true
+		case "country":
+			return contact.Country, true
+		default:
+			return "", false
+		}
+	})
+}
+
+// validateContact returns a message for every property of the contact AutoDNS would reject.
+func validateContact(contact *api.Contact) []api.Message {
+	msgs := []api.Message{}
+	invalid := func(text string) {
+		msgs = append(msgs, api.Message{Text: text, Code: "EF03020", Status: "ERROR"})
+	}
+
+	switch contact.Type {
+	case api.ContactTypePerson, api.ContactTypeRole:
+		if contact.FirstName == "" || contact.LastName == "" {
+			invalid("The first and the last name are required for contacts of the type " + contact.Type + ".")
+		}
+	case api.ContactTypeOrg:
+		if contact.Organization == "" {
+			invalid("The organization is required for contacts of the type ORG.")
+		}
+	default:
+		invalid("The contact type " + contact.Type + " is not supported.")
+	}
+
+	if len(contact.Address) == 0 || contact.City == "" || contact.PostalCode == "" {
+		invalid("The address, the city and the postal code are required.")
+	}
+
+	if len(contact.Country) != 2 || strings.ToUpper(contact.Country) != contact.Country {
+		invalid("The country must be an ISO 3166-1 alpha-2 code in upper case.")
+	}
+
+	if !strings.Contains(contact.Email, "@") {
+		invalid("The email address is invalid.")
+	}
+
+	return msgs
+}
+
+// defaultContact fills in the settings AutoDNS computes when they're not part of the payload.
+func defaultContact(contact *api.Contact) *api.Contact {
+	contact = deepCopy(contact)
+
+	if contact.Address == nil {
+		contact.Address = []string{}
+	}
+
+	if contact.Created == "" {
+		contact.Created = now()
+		contact.Updated = contact.Created
+	}
+
+	return contact
+}
@@ -0,0 +1,144 @@
+package autodnstest
+
+import (
+	"net/http"
+	"strconv"
+	"terraform-provider-autodns/internal/api"
+)
+
+// AddDomain stores the domain on the server, like a domain registered with AutoDNS.
+func (s *Server) AddDomain(domain api.Domain) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.domains[domain.Name] = defaultDomain(&domain)
+}
+
+// Domain returns a copy of the domain stored on the server.
+func (s *Server) Domain(name string) (*api.Domain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain, ok := s.domains[name]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(domain), true
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	domain, ok := s.domains[r.PathValue("name")]
+	if ok {
+		domain = deepCopy(domain)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeDomainNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0105", "Domain information inquired successfully.", domain)
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	domain := &api.Domain{}
+	if !s.decode(w, r, domain) {
+		return
+	}
+
+	if len(domain.NameServers) == 0 {
+		s.writeError(w, http.StatusBadRequest, "EF01012", "The domain data is invalid.", api.Message{
+			Text:   "At least one nameserver is required.",
+			Code:   "EF01012",
+			Status: "ERROR",
+		})
+		return
+	}
+
+	if msgs := s.validateContacts(domain); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF01012", "The domain data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	name := r.PathValue("name")
+	stored, ok := s.domains[name]
+	if ok {
+		// The domain is addressed by the path, the payload can't rename it
+		domain.Name = name
+		domain.Created = stored.Created
+		domain.Updated = now()
+		domain = defaultDomain(domain)
+		s.domains[name] = domain
+		domain = deepCopy(domain)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeDomainNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0102", "Domain updated successfully.", domain)
+}
+
+// validateContacts returns a message for every contact handle of the domain which doesn't exist.
+func (s *Server) validateContacts(domain *api.Domain) []api.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msgs := []api.Message{}
+
+	for _, ref := range []*api.ContactRef{domain.OwnerC, domain.AdminC, domain.TechC, domain.ZoneC} {
+		if ref == nil {
+			continue
+		}
+
+		if _, ok := s.contacts[ref.ID]; !ok {
+			msgs = append(msgs, api.Message{
+				Text:    "The contact handle does not exist.",
+				Code:    "EF01013",
+				Status:  "ERROR",
+				Objects: []api.MessageObject{{Type: "contact", Value: strconv.FormatInt(ref.ID, 10)}},
+			})
+		}
+	}
+
+	return msgs
+}
+
+func (s *Server) writeDomainNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0105", "Domain could not be found.", api.Message{
+		Text:    "The domain does not exist.",
+		Code:    "EF01022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "domain", Value: r.PathValue("name")}},
+	})
+}
+
+// defaultDomain fills in the settings AutoDNS computes when they're not part of the payload.
+func defaultDomain(domain *api.Domain) *api.Domain {
+	domain = deepCopy(domain)
+
+	if domain.AutoRenewStatus == "" {
+		domain.AutoRenewStatus = api.AutoRenewStatusTrue
+	}
+
+	if domain.RegistrarStatus == "" {
+		domain.RegistrarStatus = api.RegistrarStatusActive
+	}
+
+	if domain.DNSSECData == nil {
+		domain.DNSSECData = []api.DNSKey{}
+	}
+
+	if domain.Created == "" {
+		domain.Created = now()
+		domain.Updated = domain.Created
+	}
+
+	return domain
+}
@@ -0,0 +1,98 @@
+package autodnstest
+
+import (
+	"net/http"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+)
+
+// Failure describes an error response the server sends instead of handling the request.
+type Failure struct {
+	// Method and Path select the requests that fail, empty values match every request.
+	// A path ending with a slash matches every path below it.
+	Method string
+	Path   string
+
+	// Times is the number of requests that fail, zero fails every matching request.
+	Times int
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code and Text are sent as the status of the response.
+	Code string
+	Text string
+	// Messages are sent as the messages of the response.
+	Messages []api.Message
+	// RetryAfter is sent as the Retry-After header when it's set.
+	RetryAfter string
+	// Body replaces the AutoDNS error envelope when it's set, e.g. to mimic a proxy error.
+	Body string
+}
+
+// Fail makes the server answer the requests matching the failure with an error.
+// Failures are matched in the order they were added.
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &f)
+}
+
+// ClearFailures removes all failures which have not been used up yet.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// failure returns the failure for the request and uses it up, the server must be locked.
+func (s *Server) failure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if !f.matches(r) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func (f *Failure) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+
+	if strings.HasSuffix(f.Path, "/") {
+		return strings.HasPrefix(r.URL.Path, f.Path)
+	}
+
+	return f.Path == "" || f.Path == r.URL.Path
+}
+
+func (f *Failure) write(s *Server, w http.ResponseWriter) {
+	if f.RetryAfter != "" {
+		w.Header().Set("Retry-After", f.RetryAfter)
+	}
+
+	if f.Body != "" {
+		w.WriteHeader(f.StatusCode)
+		_, _ = w.Write([]byte(f.Body))
+		return
+	}
+
+	text := f.Text
+	if text == "" {
+		text = http.StatusText(f.StatusCode)
+	}
+
+	s.writeError(w, f.StatusCode, f.Code, text, f.Messages...)
+}
@@ -0,0 +1,218 @@
+package autodnstest
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+)
+
+// AddHost stores the host on the server, replacing the host with the same name.
+func (s *Server) AddHost(host api.Host) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := defaultHost(&host)
+	s.hosts[h.Name] = h
+}
+
+// Host returns a copy of the host stored on the server.
+func (s *Server) Host(name string) (*api.Host, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	host, ok := s.hosts[name]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(host), true
+}
+
+func (s *Server) createHost(w http.ResponseWriter, r *http.Request) {
+	host := &api.Host{}
+	if !s.decode(w, r, host) {
+		return
+	}
+
+	s.mu.Lock()
+	msgs := s.validateHost(host)
+	_, exists := s.hosts[host.Name]
+	if len(msgs) == 0 && !exists {
+		host = defaultHost(host)
+		host.Created = now()
+		host.Updated = host.Created
+		s.hosts[host.Name] = host
+		host = deepCopy(host)
+	}
+	s.mu.Unlock()
+
+	if len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF06020", "The host data is invalid.", msgs...)
+		return
+	}
+
+	if exists {
+		s.writeError(w, http.StatusBadRequest, "EF06021", "The host already exists.", api.Message{
+			Text:    "A host with the name already exists.",
+			Code:    "EF06021",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "host", Value: host.Name}},
+		})
+		return
+	}
+
+	s.writeData(w, "S0601", "Host created successfully.", host)
+}
+
+func (s *Server) getHost(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	host, ok := s.hosts[r.PathValue("name")]
+	if ok {
+		host = deepCopy(host)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeHostNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0605", "Host information inquired successfully.", host)
+}
+
+func (s *Server) updateHost(w http.ResponseWriter, r *http.Request) {
+	host := &api.Host{}
+	if !s.decode(w, r, host) {
+		return
+	}
+
+	// The host is addressed by the path, the payload can't rename it
+	host.Name = r.PathValue("name")
+
+	s.mu.Lock()
+	msgs := s.validateHost(host)
+	stored, ok := s.hosts[host.Name]
+	if ok && len(msgs) == 0 {
+		host.Created = stored.Created
+		host.Updated = now()
+		host = defaultHost(host)
+		s.hosts[host.Name] = host
+		host = deepCopy(host)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeHostNotFound(w, r)
+		return
+	}
+
+	if len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF06020", "The host data is invalid.", msgs...)
+		return
+	}
+
+	s.writeData(w, "S0602", "Host updated successfully.", host)
+}
+
+func (s *Server) deleteHost(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	name := r.PathValue("name")
+	_, ok := s.hosts[name]
+	used := ok && s.hostUsed(name)
+	if ok && !used {
+		delete(s.hosts, name)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeHostNotFound(w, r)
+		return
+	}
+
+	if used {
+		s.writeError(w, http.StatusBadRequest, "EF06023", "The host is still in use.", api.Message{
+			Text:    "The host is a nameserver of a nameserver group and can't be deleted.",
+			Code:    "EF06023",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "host", Value: name}},
+		})
+		return
+	}
+
+	s.writeData(w, "S0603", "Host deleted successfully.")
+}
+
+// hostUsed reports whether a nameserver group references the host, the caller must hold the lock.
+func (s *Server) hostUsed(name string) bool {
+	for _, group := range s.nameServerGroups {
+		for _, ns := range group.NameServers {
+			if ns.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ownDomain returns the domain of the account the host name is below, the caller must hold the lock.
+func (s *Server) ownDomain(name string) (string, bool) {
+	for domain := range s.domains {
+		if strings.HasSuffix(name, "."+domain) {
+			return domain, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Server) writeHostNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0605", "Host could not be found.", api.Message{
+		Text:    "The host does not exist.",
+		Code:    "EF06022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "host", Value: r.PathValue("name")}},
+	})
+}
+
+// validateHost returns a message for every property of the host AutoDNS would reject, the caller must hold the lock.
+func (s *Server) validateHost(host *api.Host) []api.Message {
+	msgs := []api.Message{}
+	invalid := func(text string) {
+		msgs = append(msgs, api.Message{Text: text, Code: "EF06020", Status: "ERROR"})
+	}
+
+	// Glue records can only be registered for the domains of the account
+	if _, ok := s.ownDomain(host.Name); !ok {
+		invalid("The host " + host.Name + " is not below a domain of the account.")
+	}
+
+	if len(host.IPAddresses) == 0 {
+		invalid("At least one IP address is required.")
+	}
+
+	for _, ip := range host.IPAddresses {
+		if net.ParseIP(ip) == nil {
+			invalid("The IP address " + ip + " is invalid.")
+		}
+	}
+
+	return msgs
+}
+
+// defaultHost fills in the settings AutoDNS computes when they're not part of the payload.
+func defaultHost(host *api.Host) *api.Host {
+	host = deepCopy(host)
+
+	if host.IPAddresses == nil {
+		host.IPAddresses = []string{}
+	}
+
+	if host.Created == "" {
+		host.Created = now()
+		host.Updated = host.Created
+	}
+
+	return host
+}
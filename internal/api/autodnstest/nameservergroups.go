@@ -0,0 +1,229 @@
+package autodnstest
+
+import (
+	"net/http"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+)
+
+// AddNameServerGroup stores the nameserver group on the server, replacing the group with the same name.
+func (s *Server) AddNameServerGroup(group api.NameServerGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := defaultNameServerGroup(&group)
+	s.nameServerGroups[g.Name] = g
+}
+
+// NameServerGroup returns a copy of the nameserver group stored on the server.
+func (s *Server) NameServerGroup(name string) (*api.NameServerGroup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.nameServerGroups[name]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(group), true
+}
+
+func (s *Server) createNameServerGroup(w http.ResponseWriter, r *http.Request) {
+	group := &api.NameServerGroup{}
+	if !s.decode(w, r, group) {
+		return
+	}
+
+	s.mu.Lock()
+	msgs := s.validateNameServerGroup(group)
+	_, exists := s.nameServerGroups[group.Name]
+	if len(msgs) == 0 && !exists {
+		group = defaultNameServerGroup(group)
+		group.Created = now()
+		group.Updated = group.Created
+		s.nameServerGroups[group.Name] = group
+		group = deepCopy(group)
+	}
+	s.mu.Unlock()
+
+	if len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF07020", "The nameserver group data is invalid.", msgs...)
+		return
+	}
+
+	if exists {
+		s.writeError(w, http.StatusBadRequest, "EF07021", "The nameserver group already exists.", api.Message{
+			Text:    "A nameserver group with the name already exists.",
+			Code:    "EF07021",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "nameserverGroup", Value: group.Name}},
+		})
+		return
+	}
+
+	s.writeData(w, "S0701", "Nameserver group created successfully.", group)
+}
+
+func (s *Server) getNameServerGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	group, ok := s.nameServerGroups[r.PathValue("name")]
+	if ok {
+		group = deepCopy(group)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeNameServerGroupNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0705", "Nameserver group information inquired successfully.", group)
+}
+
+func (s *Server) updateNameServerGroup(w http.ResponseWriter, r *http.Request) {
+	group := &api.NameServerGroup{}
+	if !s.decode(w, r, group) {
+		return
+	}
+
+	// The group is addressed by the path, the payload can't rename it
+	group.Name = r.PathValue("name")
+
+	s.mu.Lock()
+	msgs := s.validateNameServerGroup(group)
+	stored, ok := s.nameServerGroups[group.Name]
+	if ok && len(msgs) == 0 {
+		group.Created = stored.Created
+		group.Updated = now()
+		group = defaultNameServerGroup(group)
+		s.nameServerGroups[group.Name] = group
+		group = deepCopy(group)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeNameServerGroupNotFound(w, r)
+		return
+	}
+
+	if len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF07020", "The nameserver group data is invalid.", msgs...)
+		return
+	}
+
+	s.writeData(w, "S0702", "Nameserver group updated successfully.", group)
+}
+
+func (s *Server) deleteNameServerGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	name := r.PathValue("name")
+	_, ok := s.nameServerGroups[name]
+	used := ok && s.nameServerGroupUsed(name)
+	if ok && !used {
+		delete(s.nameServerGroups, name)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeNameServerGroupNotFound(w, r)
+		return
+	}
+
+	if used {
+		s.writeError(w, http.StatusBadRequest, "EF07023", "The nameserver group is still in use.", api.Message{
+			Text:    "Zones are assigned to the nameserver group, it can't be deleted.",
+			Code:    "EF07023",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "nameserverGroup", Value: name}},
+		})
+		return
+	}
+
+	s.writeData(w, "S0703", "Nameserver group deleted successfully.")
+}
+
+// nameServerGroupUsed reports whether a zone is assigned to the group, the caller must hold the lock.
+func (s *Server) nameServerGroupUsed(name string) bool {
+	for _, zone := range s.zones {
+		if zone.NameServerGroup == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validNameServerGroup reports whether the zone can be assigned to its nameserver group, the caller must hold the lock.
+// Zones can use the group of their virtual name server or one of the custom groups.
+func (s *Server) validNameServerGroup(zone *api.Zone) bool {
+	if zone.NameServerGroup == "" {
+		return true
+	}
+
+	_, group, _ := strings.Cut(zone.VirtualNameServer, ".")
+	_, custom := s.nameServerGroups[zone.NameServerGroup]
+
+	return zone.NameServerGroup == group || custom
+}
+
+func (s *Server) writeNameServerGroupNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0705", "Nameserver group could not be found.", api.Message{
+		Text:    "The nameserver group does not exist.",
+		Code:    "EF07022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "nameserverGroup", Value: r.PathValue("name")}},
+	})
+}
+
+// writeNameServerGroupInvalid writes the error for zones assigned to a nameserver group they can't use.
+func (s *Server) writeNameServerGroupInvalid(w http.ResponseWriter, zone *api.Zone) {
+	s.writeError(w, http.StatusBadRequest, "EF02020", "The zone data is invalid.", api.Message{
+		Text:    "The nameserver group " + zone.NameServerGroup + " does not exist.",
+		Code:    "EF07022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "nameserverGroup", Value: zone.NameServerGroup}},
+	})
+}
+
+// validateNameServerGroup returns a message for every property of the group AutoDNS would reject, the caller must hold the lock.
+func (s *Server) validateNameServerGroup(group *api.NameServerGroup) []api.Message {
+	msgs := []api.Message{}
+	invalid := func(text string) {
+		msgs = append(msgs, api.Message{Text: text, Code: "EF07020", Status: "ERROR"})
+	}
+
+	if group.Name == "" || strings.ContainsAny(group.Name, "/@ ") {
+		invalid("The name of the nameserver group is invalid.")
+	}
+
+	if len(group.NameServers) < 2 {
+		invalid("At least two nameservers are required.")
+	}
+
+	for _, ns := range group.NameServers {
+		// Nameservers below a domain of the account need a host with their glue records
+		if _, own := s.ownDomain(ns.Name); own {
+			if _, ok := s.hosts[ns.Name]; !ok {
+				invalid("The nameserver " + ns.Name + " has no host with its IP addresses.")
+			}
+		}
+	}
+
+	return msgs
+}
+
+// defaultNameServerGroup fills in the settings AutoDNS computes when they're not part of the payload.
+func defaultNameServerGroup(group *api.NameServerGroup) *api.NameServerGroup {
+	group = deepCopy(group)
+
+	if group.NameServers == nil {
+		group.NameServers = []api.NameServer{}
+	}
+
+	if group.Created == "" {
+		group.Created = now()
+		group.Updated = group.Created
+	}
+
+	return group
+}
@@ -0,0 +1,279 @@
+package autodnstest
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+)
+
+// AddRedirect stores the redirect on the server, replacing the redirect with the same source.
+func (s *Server) AddRedirect(redirect api.Redirect) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.defaultRedirect(&redirect)
+	s.redirects[r.Source] = r
+}
+
+// Redirect returns a copy of the redirect stored on the server.
+func (s *Server) Redirect(source string) (*api.Redirect, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	redirect, ok := s.redirects[source]
+	if !ok {
+		return nil, false
+	}
+
+	return deepCopy(redirect), true
+}
+
+func (s *Server) createRedirect(w http.ResponseWriter, r *http.Request) {
+	redirect := &api.Redirect{}
+	if !s.decode(w, r, redirect) {
+		return
+	}
+
+	if msgs := validateRedirect(redirect); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF05020", "The redirect data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	_, exists := s.redirects[redirect.Source]
+	if !exists {
+		redirect = s.defaultRedirect(redirect)
+		redirect.Created = now()
+		redirect.Updated = redirect.Created
+		s.redirects[redirect.Source] = redirect
+		redirect = deepCopy(redirect)
+	}
+	s.mu.Unlock()
+
+	if exists {
+		s.writeError(w, http.StatusBadRequest, "EF05021", "The redirect already exists.", api.Message{
+			Text:    "A redirect for the source already exists.",
+			Code:    "EF05021",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "redirect", Value: redirect.Source}},
+		})
+		return
+	}
+
+	s.writeData(w, "S0501", "Redirect created successfully.", redirect)
+}
+
+func (s *Server) searchRedirects(w http.ResponseWriter, r *http.Request) {
+	zf := &api.SearchFilterReq{}
+	if !s.decode(w, r, zf) {
+		return
+	}
+
+	s.mu.Lock()
+	redirects := []any{}
+	for _, redirect := range s.redirects {
+		if matchRedirect(redirect, zf.Filters) {
+			redirects = append(redirects, deepCopy(redirect))
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(redirects, func(a, b any) int {
+		return strings.Compare(a.(*api.Redirect).Source, b.(*api.Redirect).Source)
+	})
+
+	// Searches without a view are truncated
+	view := api.View{Limit: s.SearchLimit}
+	if zf.View != nil {
+		view = *zf.View
+	}
+
+	total := len(redirects)
+	redirects = redirects[min(view.Offset, len(redirects)):]
+	redirects = redirects[:min(view.Limit, len(redirects))]
+
+	s.writeSearchData(w, "S0505", "Redirects searched successfully.", "Redirect", total, redirects...)
+}
+
+func (s *Server) getRedirect(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	redirect, ok := s.redirects[r.PathValue("source")]
+	if ok {
+		redirect = deepCopy(redirect)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeRedirectNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0505", "Redirect information inquired successfully.", redirect)
+}
+
+func (s *Server) updateRedirect(w http.ResponseWriter, r *http.Request) {
+	redirect := &api.Redirect{}
+	if !s.decode(w, r, redirect) {
+		return
+	}
+
+	// The redirect is addressed by the path, the payload can't change its source
+	redirect.Source = r.PathValue("source")
+
+	if msgs := validateRedirect(redirect); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF05020", "The redirect data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	stored, ok := s.redirects[redirect.Source]
+	if ok {
+		redirect.Created = stored.Created
+		redirect.Updated = now()
+		redirect = s.defaultRedirect(redirect)
+		s.redirects[redirect.Source] = redirect
+		redirect = deepCopy(redirect)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeRedirectNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0502", "Redirect updated successfully.", redirect)
+}
+
+func (s *Server) deleteRedirect(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	source := r.PathValue("source")
+	_, ok := s.redirects[source]
+	delete(s.redirects, source)
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeRedirectNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0503", "Redirect deleted successfully.")
+}
+
+func (s *Server) writeRedirectNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0505", "Redirect could not be found.", api.Message{
+		Text:    "The redirect does not exist.",
+		Code:    "EF05022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "redirect", Value: r.PathValue("source")}},
+	})
+}
+
+// matchRedirect reports whether the redirect matches the filters.
+func matchRedirect(redirect *api.Redirect, filters []api.SearchFilter) bool {
+	return matchFilters(filters, func(key string) (string, bool) {
+		switch key {
+		case "source":
+			return redirect.Source, true
+		case "target":
+			return redirect.Target, true
+		case "type":
+			return redirect.Type, true
+		case "mode":
+			return redirect.Mode, true
+		case "domain":
+			return redirect.Domain, true
+		case "created":
+			return redirect.Created, true
+		case "updated":
+			return redirect.Updated, true
+		default:
+			return "", false
+		}
+	})
+}
+
+// validateRedirect returns a message for every property of the redirect AutoDNS would reject.
+func validateRedirect(redirect *api.Redirect) []api.Message {
+	msgs := []api.Message{}
+	invalid := func(text string) {
+		msgs = append(msgs, api.Message{Text: text, Code: "EF05020", Status: "ERROR"})
+	}
+
+	switch redirect.Type {
+	case api.RedirectTypeDomain:
+		if redirect.Source == "" || strings.ContainsAny(redirect.Source, "/@ ") {
+			invalid("The source of a domain redirect must be a domain.")
+		}
+
+		if u, err := url.Parse(redirect.Target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalid("The target of a domain redirect must be a http or https URL.")
+		}
+
+		if !slices.Contains([]string{api.RedirectModeHTTP, api.RedirectModeHTTPX, api.RedirectModeFrame}, redirect.Mode) {
+			invalid("The mode " + redirect.Mode + " is not supported for domain redirects.")
+		}
+
+		if redirect.Title != "" && redirect.Mode != api.RedirectModeFrame {
+			invalid("Only frame redirects can have a title.")
+		}
+	case api.RedirectTypeEmail:
+		switch redirect.Mode {
+		case api.RedirectModeCatchAll:
+			if redirect.Source == "" || strings.ContainsAny(redirect.Source, "/@ ") {
+				invalid("The source of a catch-all email redirect must be a domain.")
+			}
+		case api.RedirectModeSingle:
+			if !strings.Contains(redirect.Source, "@") {
+				invalid("The source of a single email redirect must be an email address.")
+			}
+		default:
+			invalid("The mode " + redirect.Mode + " is not supported for email redirects.")
+		}
+
+		if !strings.Contains(redirect.Target, "@") {
+			invalid("The target of an email redirect must be an email address.")
+		}
+
+		if redirect.Title != "" || redirect.ForwardPath || redirect.ForwardQuery {
+			invalid("Email redirects can't have a title or forward the path and query.")
+		}
+	default:
+		invalid("The redirect type " + redirect.Type + " is not supported.")
+	}
+
+	return msgs
+}
+
+// defaultRedirect fills in the settings AutoDNS computes when they're not part of the payload, the caller must hold the lock.
+func (s *Server) defaultRedirect(redirect *api.Redirect) *api.Redirect {
+	redirect = deepCopy(redirect)
+
+	// The domain is the zone the source belongs to, or the domain part of the source
+	if redirect.Domain == "" {
+		_, host, ok := strings.Cut(redirect.Source, "@")
+		if !ok {
+			host = redirect.Source
+		}
+
+		redirect.Domain = host
+		best := ""
+		for _, zone := range s.zones {
+			if (host == zone.Origin || strings.HasSuffix(host, "."+zone.Origin)) && len(zone.Origin) > len(best) {
+				best = zone.Origin
+			}
+		}
+
+		if best != "" {
+			redirect.Domain = best
+		}
+	}
+
+	if redirect.Created == "" {
+		redirect.Created = now()
+		redirect.Updated = redirect.Created
+	}
+
+	return redirect
+}
@@ -0,0 +1,400 @@
+// Package autodnstest provides an in-memory AutoDNS API server for unit and acceptance tests.
+package autodnstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"terraform-provider-autodns/internal/api"
+	"time"
+)
+
+const (
+	// DefaultUsername is the username accepted by a new server.
+	DefaultUsername = "autodnstest"
+	// DefaultPassword is the password accepted by a new server.
+	DefaultPassword = "autodnstest"
+	// DefaultContext is the context accepted by a new server.
+	DefaultContext = "4"
+	// DefaultSearchLimit is the number of results returned by searches without a view.
+	DefaultSearchLimit = 50
+)
+
+// Request describes a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Owner  api.Owner
+}
+
+// Server is an in-memory implementation of the zone, domain, contact, host, nameserver group, certificate and redirect endpoints of the AutoDNS API.
+// It checks the credentials and the context of every request like AutoDNS does.
+type Server struct {
+	*httptest.Server
+
+	Username string
+	Password string
+	Context  string
+
+	// Owners are the subusers requests may be sent on behalf of, requests for other owners are rejected.
+	Owners []api.Owner
+
+	// SearchLimit is the number of results returned by searches without a view, like AutoDNS truncating them.
+	SearchLimit int
+
+	// CertificateValidity is how long the issued certificates are valid.
+	CertificateValidity time.Duration
+
+	// TOTPSecret makes the server require a valid one-time code, like for accounts with two-factor authentication.
+	TOTPSecret string
+
+	mu               sync.Mutex
+	zones            map[api.ZoneID]*api.Zone
+	domains          map[string]*api.Domain
+	contacts         map[int64]*api.Contact
+	hosts            map[string]*api.Host
+	nameServerGroups map[string]*api.NameServerGroup
+	certificates     map[int64]*api.Certificate
+	redirects        map[string]*api.Redirect
+	ca               *certificateAuthority
+	sessions         map[string]bool
+	failures         []*Failure
+	requests         []Request
+	stid             int
+}
+
+// NewServer starts a new server without any zones, it must be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		Context:  DefaultContext,
+
+		SearchLimit:         DefaultSearchLimit,
+		CertificateValidity: DefaultCertificateValidity,
+
+		zones:            map[api.ZoneID]*api.Zone{},
+		domains:          map[string]*api.Domain{},
+		contacts:         map[int64]*api.Contact{},
+		hosts:            map[string]*api.Host{},
+		nameServerGroups: map[string]*api.NameServerGroup{},
+		certificates:     map[int64]*api.Certificate{},
+		redirects:        map[string]*api.Redirect{},
+		sessions:         map[string]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", s.login)
+	mux.HandleFunc("POST /logout", s.logout)
+	mux.HandleFunc("POST /zone", s.createZone)
+	mux.HandleFunc("POST /zone/_search", s.searchZones)
+	mux.HandleFunc("GET /zone/{origin}/{vns}", s.getZone)
+	mux.HandleFunc("PUT /zone/{origin}/{vns}", s.updateZone)
+	mux.HandleFunc("DELETE /zone/{origin}/{vns}", s.deleteZone)
+	mux.HandleFunc("POST /zone/{origin}/{vns}/_keyrollover", s.rolloverKeys)
+	mux.HandleFunc("POST /zone/{origin}/_stream", s.streamZone)
+	mux.HandleFunc("POST /zone/{origin}/{vns}/_stream", s.streamZone)
+	mux.HandleFunc("GET /domain/{name}", s.getDomain)
+	mux.HandleFunc("PUT /domain/{name}", s.updateDomain)
+	mux.HandleFunc("POST /contact", s.createContact)
+	mux.HandleFunc("POST /contact/_search", s.searchContacts)
+	mux.HandleFunc("GET /contact/{id}", s.getContact)
+	mux.HandleFunc("PUT /contact/{id}", s.updateContact)
+	mux.HandleFunc("DELETE /contact/{id}", s.deleteContact)
+	mux.HandleFunc("POST /host", s.createHost)
+	mux.HandleFunc("GET /host/{name}", s.getHost)
+	mux.HandleFunc("PUT /host/{name}", s.updateHost)
+	mux.HandleFunc("DELETE /host/{name}", s.deleteHost)
+	mux.HandleFunc("POST /nameserverGroup", s.createNameServerGroup)
+	mux.HandleFunc("GET /nameserverGroup/{name}", s.getNameServerGroup)
+	mux.HandleFunc("PUT /nameserverGroup/{name}", s.updateNameServerGroup)
+	mux.HandleFunc("DELETE /nameserverGroup/{name}", s.deleteNameServerGroup)
+	mux.HandleFunc("POST /certificate/_prepareOrder", s.prepareCertificateOrder)
+	mux.HandleFunc("POST /certificate", s.createCertificate)
+	mux.HandleFunc("GET /certificate/{id}", s.getCertificate)
+	mux.HandleFunc("POST /certificate/{id}/_renew", s.renewCertificate)
+	mux.HandleFunc("DELETE /certificate/{id}", s.deleteCertificate)
+	mux.HandleFunc("POST /redirect", s.createRedirect)
+	mux.HandleFunc("POST /redirect/_search", s.searchRedirects)
+	mux.HandleFunc("GET /redirect/{source}", s.getRedirect)
+	mux.HandleFunc("PUT /redirect/{source}", s.updateRedirect)
+	mux.HandleFunc("DELETE /redirect/{source}", s.deleteRedirect)
+
+	s.Server = httptest.NewServer(s.intercept(mux))
+
+	return s
+}
+
+// APIClient returns an API client configured for the server.
+// Retries are sent without waiting, so tests for transient failures stay fast.
+func (s *Server) APIClient() *api.Client {
+	c := api.NewClient(s.URL, s.Context, s.Username, s.Password)
+	c.TOTPSecret = s.TOTPSecret
+	c.RetryWaitMin = time.Millisecond
+	c.RetryWaitMax = time.Millisecond
+
+	return c
+}
+
+// AddZone stores the zone on the server, replacing the zone with the same ID.
+func (s *Server) AddZone(zone api.Zone) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.zones[zone.ID()] = defaultZone(&zone)
+}
+
+// Zone returns a copy of the zone stored on the server.
+func (s *Server) Zone(zoneID api.ZoneID) (*api.Zone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.zones[zoneID]
+	if !ok {
+		return nil, false
+	}
+
+	return copyZone(zone), true
+}
+
+// Requests returns the requests received by the server so far, including rejected ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// intercept records the request, checks the authentication and injects the configured failures.
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		owner := api.Owner{
+			User:    r.Header.Get("X-Domainrobot-Owner-User"),
+			Context: r.Header.Get("X-Domainrobot-Owner-Context"),
+		}
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Owner: owner})
+		f := s.failure(r)
+		s.mu.Unlock()
+
+		if f != nil {
+			f.write(s, w)
+			return
+		}
+
+		// The login endpoint checks the credentials of the payload
+		if r.URL.Path == "/login" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !s.authenticated(w, r) {
+			return
+		}
+
+		if owner != (api.Owner{}) && !slices.Contains(s.Owners, owner) {
+			s.writeError(w, http.StatusForbidden, "EF00000", "The owner is not a subuser of the user.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticated checks the session or the credentials of the request, writing an error when they're rejected.
+func (s *Server) authenticated(w http.ResponseWriter, r *http.Request) bool {
+	if id := r.Header.Get(api.SessionHeader); id != "" {
+		if !s.validSession(id) {
+			s.writeError(w, http.StatusUnauthorized, "EF00000", "The session is invalid or has expired.")
+			return false
+		}
+
+		return true
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.Username || password != s.Password {
+		s.writeError(w, http.StatusUnauthorized, "EF00000", "Authentication error.")
+		return false
+	}
+
+	if s.TOTPSecret != "" && !s.validTOTP(r.Header.Get("X-Domainrobot-2FA-Token")) {
+		s.writeError(w, http.StatusUnauthorized, "EF00000", "Two-factor authentication failed.")
+		return false
+	}
+
+	// Sessions are bound to the context they logged in with
+	if r.Header.Get("X-Domainrobot-Context") != s.Context {
+		s.writeError(w, http.StatusForbidden, "EF00000", "The user is not assigned to the requested context.")
+		return false
+	}
+
+	return true
+}
+
+// validTOTP reports whether the one-time code is valid for the current or the previous time step.
+func (s *Server) validTOTP(code string) bool {
+	now := time.Now()
+	for _, t := range []time.Time{now, now.Add(-30 * time.Second)} {
+		if want, err := api.TOTPCode(s.TOTPSecret, t); err == nil && code == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeData writes a successful response with the objects as data.
+func (s *Server) writeData(w http.ResponseWriter, code, text string, data ...any) {
+	if data == nil {
+		data = []any{}
+	}
+
+	s.write(w, http.StatusOK, map[string]any{
+		"stid":   s.nextSTID(),
+		"status": api.ResponseStatus{Code: code, Text: text, Type: "SUCCESS"},
+		"data":   data,
+	})
+}
+
+// writeSearchData writes a page of search results, the summary is the total number of results.
+func (s *Server) writeSearchData(w http.ResponseWriter, code, text, objectType string, summary int, data ...any) {
+	if data == nil {
+		data = []any{}
+	}
+
+	s.write(w, http.StatusOK, map[string]any{
+		"stid":   s.nextSTID(),
+		"status": api.ResponseStatus{Code: code, Text: text, Type: "SUCCESS"},
+		"object": api.ResponseObject{Type: objectType, Summary: summary},
+		"data":   data,
+	})
+}
+
+// writeError writes an error response in the AutoDNS format.
+func (s *Server) writeError(w http.ResponseWriter, statusCode int, code, text string, messages ...api.Message) {
+	s.write(w, statusCode, api.Error{
+		STID:     s.nextSTID(),
+		Status:   api.ResponseStatus{Code: code, Text: text, Type: "ERROR"},
+		Messages: messages,
+	})
+}
+
+func (s *Server) write(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// nextSTID returns a unique server transaction ID, AutoDNS adds one to every response.
+func (s *Server) nextSTID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stid++
+
+	return fmt.Sprintf("autodnstest-%d", s.stid)
+}
+
+// decode reads the JSON payload of the request, writing a validation error when it's malformed.
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		s.writeError(w, http.StatusBadRequest, "EF00001", "The request could not be parsed.", api.Message{
+			Text:   err.Error(),
+			Status: "ERROR",
+		})
+		return false
+	}
+
+	return true
+}
+
+// zoneID returns the ID of the zone addressed by the request path.
+// Paths without a virtual name server address the only zone with the origin.
+func (s *Server) zoneID(r *http.Request) (api.ZoneID, bool) {
+	zoneID := api.ZoneID{Origin: r.PathValue("origin"), VirtualNameServer: r.PathValue("vns")}
+	if zoneID.VirtualNameServer != "" {
+		_, ok := s.zones[zoneID]
+		return zoneID, ok
+	}
+
+	var found []api.ZoneID
+	for id := range s.zones {
+		if id.Origin == zoneID.Origin {
+			found = append(found, id)
+		}
+	}
+
+	if len(found) != 1 {
+		return zoneID, false
+	}
+
+	return found[0], true
+}
+
+// writeZoneNotFound writes the error AutoDNS returns for unknown zones.
+func (s *Server) writeZoneNotFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "E0205", "Zone could not be found.", api.Message{
+		Text:    "The zone does not exist.",
+		Code:    "EF02022",
+		Status:  "ERROR",
+		Objects: []api.MessageObject{{Type: "zone", Value: strings.TrimPrefix(r.URL.Path, "/zone/")}},
+	})
+}
+
+// defaultZone fills in the settings AutoDNS computes when they're not part of the payload.
+func defaultZone(zone *api.Zone) *api.Zone {
+	zone = copyZone(zone)
+
+	if zone.NameServerGroup == "" {
+		_, group, _ := strings.Cut(zone.VirtualNameServer, ".")
+		zone.NameServerGroup = group
+	}
+
+	if zone.Type == "" {
+		zone.Type = api.ZoneTypePrimary
+	}
+
+	if zone.SOA == nil {
+		zone.SOA = &api.SOA{Refresh: 43200, Retry: 7200, Expire: 1209600, TTL: 86400}
+	}
+
+	if zone.SOA.Email == "" {
+		zone.SOA.Email = "hostmaster@" + zone.Origin
+	}
+
+	if zone.Records == nil {
+		zone.Records = []api.Record{}
+	}
+
+	if zone.Created == "" {
+		zone.Created = now()
+		zone.Updated = zone.Created
+	}
+
+	return zone
+}
+
+// copyZone returns a deep copy of the zone, so the stored zones can't be modified by callers.
+func copyZone(zone *api.Zone) *api.Zone {
+	return deepCopy(zone)
+}
+
+// deepCopy copies the object through its JSON representation.
+func deepCopy[T any](v *T) *T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	c := new(T)
+	if err := json.Unmarshal(b, c); err != nil {
+		panic(err)
+	}
+
+	return c
+}
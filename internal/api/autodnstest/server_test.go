@@ -0,0 +1,916 @@
+package autodnstest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"terraform-provider-autodns/internal/api"
+	"testing"
+	"time"
+)
+
+var testZoneID = api.ZoneID{Origin: "example.com", VirtualNameServer: "a.ns14.net"}
+
+func TestServerZoneLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	created, err := c.CreateZone(ctx, &api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+	if err != nil {
+		t.Fatalf("CreateZone() error = %v", err)
+	}
+
+	if created.NameServerGroup != "ns14.net" || created.SOA == nil || created.SOA.Email != "hostmaster@example.com" {
+		t.Errorf("CreateZone() = %+v, want the AutoDNS defaults", created)
+	}
+
+	if _, err := c.CreateZone(ctx, &api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer}); !api.IsValidation(err) {
+		t.Errorf("CreateZone() of an existing zone error = %v, want a validation error", err)
+	}
+
+	found, err := c.GetZone(ctx, testZoneID.Origin, "")
+	if err != nil || found.ID() != testZoneID {
+		t.Fatalf("GetZone() = %v, %v", found, err)
+	}
+
+	// The origin is ambiguous once it exists on another virtual name server
+	other := api.ZoneID{Origin: testZoneID.Origin, VirtualNameServer: "b.ns14.net"}
+	if _, err := c.CreateZone(ctx, &api.Zone{Origin: other.Origin, VirtualNameServer: other.VirtualNameServer}); err != nil {
+		t.Fatalf("CreateZone() error = %v", err)
+	}
+
+	if _, err := c.GetZone(ctx, testZoneID.Origin, ""); err == nil {
+		t.Errorf("GetZone() of an ambiguous origin should fail")
+	}
+
+	found, err = c.GetZone(ctx, testZoneID.Origin, testZoneID.VirtualNameServer)
+	if err != nil || found.ID() != testZoneID {
+		t.Fatalf("GetZone() with the virtual name server = %v, %v", found, err)
+	}
+
+	if err := c.DeleteZone(ctx, other); err != nil {
+		t.Fatalf("DeleteZone() error = %v", err)
+	}
+
+	err = c.CreateRecords(ctx, testZoneID, []api.Record{{Name: "www", Type: "A", TTL: 60, Value: "1.1.1.1"}})
+	if err != nil {
+		t.Fatalf("CreateRecords() error = %v", err)
+	}
+
+	err = c.UpdateRecords(ctx, testZoneID,
+		[]api.Record{{Name: "www", Type: "A", TTL: 60, Value: "1.1.1.1"}},
+		[]api.Record{{Name: "www", Type: "A", TTL: 60, Value: "2.2.2.2"}},
+	)
+	if err != nil {
+		t.Fatalf("UpdateRecords() error = %v", err)
+	}
+
+	records, err := c.GetRecords(ctx, testZoneID)
+	if err != nil {
+		t.Fatalf("GetRecords() error = %v", err)
+	}
+
+	if len(records) != 1 || records[0].Value != "2.2.2.2" {
+		t.Errorf("GetRecords() = %+v, want the updated record", records)
+	}
+
+	if err := c.DeleteZone(ctx, testZoneID); err != nil {
+		t.Fatalf("DeleteZone() error = %v", err)
+	}
+
+	if _, err := c.GetZoneByID(ctx, testZoneID); !api.IsNotFound(err) {
+		t.Errorf("GetZoneByID() of a deleted zone error = %v, want not found", err)
+	}
+}
+
+func TestServerRejectsInvalidRecords(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	c.BatchWindow = 0
+
+	err := c.CreateRecords(context.Background(), testZoneID, []api.Record{{Name: "www", Type: "A", TTL: 60, Value: "::1"}})
+	if !api.IsValidation(err) {
+		t.Fatalf("CreateRecords() error = %v, want a validation error", err)
+	}
+
+	zone, _ := s.Zone(testZoneID)
+	if len(zone.Records) != 0 {
+		t.Errorf("rejected records have been stored: %+v", zone.Records)
+	}
+}
+
+func TestServerSearchZones(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	// More zones than fit on one page of search results
+	for i := range api.DefaultSearchPageSize + 5 {
+		s.AddZone(api.Zone{Origin: fmt.Sprintf("zone%03d.com", i), VirtualNameServer: "a.ns14.net"})
+	}
+	s.AddZone(api.Zone{Origin: "example.org", VirtualNameServer: "a.ns14.net"})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	zones, err := c.SearchZones(ctx, nil)
+	if err != nil {
+		t.Fatalf("SearchZones() error = %v", err)
+	}
+
+	if len(zones) != api.DefaultSearchPageSize+6 {
+		t.Errorf("SearchZones() returned %d zones, want all of them", len(zones))
+	}
+
+	zones, err = c.SearchZones(ctx, []api.SearchFilter{
+		{Key: "virtualNameServer", Value: "a.ns14.net", Operator: "EQUAL"},
+		{Filters: []api.SearchFilter{
+			{Key: "origin", Value: "*.org", Operator: "LIKE", Link: "OR"},
+			{Key: "origin", Value: "zone001.com", Operator: "EQUAL"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("SearchZones() error = %v", err)
+	}
+
+	if len(zones) != 2 || zones[0].Origin != "example.org" || zones[1].Origin != "zone001.com" {
+		t.Errorf("SearchZones() = %+v, want the zones matching the nested filters", zones)
+	}
+}
+
+func TestServerSearchPages(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for i := range 25 {
+		s.AddZone(api.Zone{Origin: fmt.Sprintf("zone%02d.com", i), VirtualNameServer: "a.ns14.net"})
+	}
+
+	it := s.APIClient().IterateZones(context.Background(), nil)
+	it.PageSize = 10
+
+	zones, err := it.All()
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+
+	if len(zones) != 25 || zones[24].Origin != "zone24.com" {
+		t.Errorf("All() returned %d zones, want all 25 of them", len(zones))
+	}
+
+	if it.Total() != 25 {
+		t.Errorf("Total() = %d, want 25", it.Total())
+	}
+
+	if n := countRequests(s, "/zone/_search"); n != 3 {
+		t.Errorf("%d search requests have been sent, want 3 pages", n)
+	}
+}
+
+func TestServerDNSSEC(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	if _, err := c.RolloverDNSSECKeys(ctx, testZoneID); !api.IsValidation(err) {
+		t.Errorf("RolloverDNSSECKeys() of an unsigned zone error = %v, want a validation error", err)
+	}
+
+	zone, err := c.SetZoneDNSSEC(ctx, testZoneID, true)
+	if err != nil {
+		t.Fatalf("SetZoneDNSSEC() error = %v", err)
+	}
+
+	if !zone.DNSSEC || len(zone.DNSSECKeys) != 2 {
+		t.Fatalf("SetZoneDNSSEC() = %+v, want a signed zone with keys", zone)
+	}
+
+	rolled, err := c.RolloverDNSSECKeys(ctx, testZoneID)
+	if err != nil {
+		t.Fatalf("RolloverDNSSECKeys() error = %v", err)
+	}
+
+	if rolled.DNSSECKeys[0].PublicKey == zone.DNSSECKeys[0].PublicKey {
+		t.Error("RolloverDNSSECKeys() kept the old keys")
+	}
+
+	zone, err = c.SetZoneDNSSEC(ctx, testZoneID, false)
+	if err != nil {
+		t.Fatalf("SetZoneDNSSEC() error = %v", err)
+	}
+
+	if zone.DNSSEC || len(zone.DNSSECKeys) != 0 {
+		t.Errorf("SetZoneDNSSEC() = %+v, want an unsigned zone", zone)
+	}
+}
+
+func TestServerConcurrentZoneWrites(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	c.BatchWindow = 0
+	ctx := context.Background()
+
+	// Warm up the cache, the zone settings must not be written back from it
+	if _, err := c.GetZoneByID(ctx, testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+
+			err := c.CreateRecords(ctx, testZoneID, []api.Record{{Name: fmt.Sprintf("host%d", i), Type: "A", TTL: 60, Value: "192.0.2.1"}})
+			if err != nil {
+				t.Errorf("CreateRecords() error = %v", err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.SetZoneDNSSEC(ctx, testZoneID, true); err != nil {
+				t.Errorf("SetZoneDNSSEC() error = %v", err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.SetZoneTransfer(ctx, testZoneID, api.ZoneTransfer{Grants: []string{"192.0.2.2"}}); err != nil {
+				t.Errorf("SetZoneTransfer() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	zone, _ := s.Zone(testZoneID)
+	if len(zone.Records) != 10 || !zone.DNSSEC || len(zone.Grants) != 1 {
+		t.Errorf("zone = %+v, want all records and settings of the concurrent writes", zone)
+	}
+}
+
+func TestServerModifyRecords(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	managed := api.Record{Name: "managed", Type: "A", TTL: 60, Value: "192.0.2.1"}
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer, Records: []api.Record{managed}})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	// Warm up the cache, the records added on the server afterwards must still be seen
+	if _, err := c.GetZoneByID(ctx, testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() error = %v", err)
+	}
+
+	manual := api.Record{Name: "manual", Type: "TXT", TTL: 60, Value: "kept"}
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer, Records: []api.Record{managed, manual}})
+
+	desired := []api.Record{{Name: "managed", Type: "A", TTL: 60, Value: "192.0.2.2"}}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := c.CreateRecords(ctx, testZoneID, []api.Record{{Name: fmt.Sprintf("host%d", i), Type: "A", TTL: 60, Value: "192.0.2.1"}})
+			if err != nil {
+				t.Errorf("CreateRecords() error = %v", err)
+			}
+		}()
+	}
+
+	var seen []api.Record
+	err := c.ModifyRecords(ctx, testZoneID, func(records []api.Record) (api.ZoneStream, error) {
+		seen = records
+
+		owned := slices.DeleteFunc(slices.Clone(records), func(r api.Record) bool { return r.Name != "managed" })
+
+		return api.DiffRecords(owned, desired), nil
+	})
+	if err != nil {
+		t.Fatalf("ModifyRecords() error = %v", err)
+	}
+	wg.Wait()
+
+	if !slices.ContainsFunc(seen, manual.Equal) {
+		t.Errorf("ModifyRecords() diffed against %+v, want the records of the server instead of the cache", seen)
+	}
+
+	zone, _ := s.Zone(testZoneID)
+	if len(zone.Records) != 12 || !slices.ContainsFunc(zone.Records, desired[0].Equal) || slices.ContainsFunc(zone.Records, managed.Equal) {
+		t.Errorf("records = %+v, want the desired, the manual and all concurrently created records", zone.Records)
+	}
+
+	// An empty stream isn't sent
+	err = c.ModifyRecords(ctx, testZoneID, func(records []api.Record) (api.ZoneStream, error) {
+		return api.DiffRecords(records, records), nil
+	})
+	if err != nil {
+		t.Errorf("ModifyRecords() of an unchanged zone error = %v", err)
+	}
+}
+
+func TestServerZoneTransfer(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	zone, err := c.GetZoneByID(ctx, testZoneID)
+	if err != nil {
+		t.Fatalf("GetZoneByID() error = %v", err)
+	}
+
+	if zone.Type != api.ZoneTypePrimary {
+		t.Errorf("GetZoneByID() type = %q, want %q", zone.Type, api.ZoneTypePrimary)
+	}
+
+	if _, err := c.SetZoneTransfer(ctx, testZoneID, api.ZoneTransfer{Type: api.ZoneTypeSecondary}); !api.IsValidation(err) {
+		t.Errorf("SetZoneTransfer() of a secondary zone without primaries error = %v, want a validation error", err)
+	}
+
+	if _, err := c.SetZoneTransfer(ctx, testZoneID, api.ZoneTransfer{Type: api.ZoneTypePrimary, Primaries: []string{"192.0.2.1"}}); !api.IsValidation(err) {
+		t.Errorf("SetZoneTransfer() of a primary zone with primaries error = %v, want a validation error", err)
+	}
+
+	if _, err := c.SetZoneTransfer(ctx, testZoneID, api.ZoneTransfer{Grants: []string{"ns.example.com"}}); !api.IsValidation(err) {
+		t.Errorf("SetZoneTransfer() with a host name grant error = %v, want a validation error", err)
+	}
+
+	transfer := api.ZoneTransfer{
+		Type:      api.ZoneTypeSecondary,
+		Primaries: []string{"192.0.2.1"},
+		Grants:    []string{"192.0.2.2", "2001:db8::2"},
+		Notifies:  []string{"192.0.2.3"},
+	}
+
+	zone, err = c.SetZoneTransfer(ctx, testZoneID, transfer)
+	if err != nil {
+		t.Fatalf("SetZoneTransfer() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(zone.ZoneTransfer, transfer) {
+		t.Errorf("SetZoneTransfer() = %+v, want %+v", zone.ZoneTransfer, transfer)
+	}
+
+	zone, err = c.SetZoneTransfer(ctx, testZoneID, api.ZoneTransfer{})
+	if err != nil {
+		t.Fatalf("SetZoneTransfer() error = %v", err)
+	}
+
+	if zone.Type != api.ZoneTypePrimary || len(zone.Primaries) != 0 || len(zone.Grants) != 0 {
+		t.Errorf("SetZoneTransfer() = %+v, want a primary zone without transfer settings", zone.ZoneTransfer)
+	}
+}
+
+func TestServerDomains(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddDomain(api.Domain{Name: "example.com", NameServers: []api.NameServer{{Name: "a.ns14.net"}}})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	if _, err := c.GetDomain(ctx, "missing.com"); !api.IsNotFound(err) {
+		t.Errorf("GetDomain() of a missing domain error = %v, want a not found error", err)
+	}
+
+	domain, err := c.GetDomain(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("GetDomain() error = %v", err)
+	}
+
+	if domain.AutoRenewStatus != api.AutoRenewStatusTrue || domain.RegistrarStatus != api.RegistrarStatusActive {
+		t.Errorf("GetDomain() = %+v, want the default statuses", domain)
+	}
+
+	domain.NameServers = []api.NameServer{{Name: "b.ns14.net"}, {Name: "c.ns14.net"}}
+	domain.RegistrarStatus = api.RegistrarStatusLock
+	domain.OwnerC = &api.ContactRef{ID: 42}
+
+	if _, err := c.UpdateDomain(ctx, domain); !api.IsValidation(err) {
+		t.Errorf("UpdateDomain() with a missing contact error = %v, want a validation error", err)
+	}
+
+	s.AddContact(api.Contact{ID: 42, Type: api.ContactTypeOrg, Organization: "Example", Country: "DE"})
+
+	updated, err := c.UpdateDomain(ctx, domain)
+	if err != nil {
+		t.Fatalf("UpdateDomain() error = %v", err)
+	}
+
+	if len(updated.NameServers) != 2 || updated.RegistrarStatus != api.RegistrarStatusLock || updated.OwnerC.ID != 42 {
+		t.Errorf("UpdateDomain() = %+v, want the updated domain", updated)
+	}
+
+	if stored, _ := s.Domain("example.com"); stored.Created != domain.Created {
+		t.Errorf("UpdateDomain() changed the creation date to %s, want %s", stored.Created, domain.Created)
+	}
+}
+
+func TestServerContacts(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	contact := &api.Contact{
+		Type:      api.ContactTypePerson,
+		Alias:     "hostmaster",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Address:   []string{"Main Street 1"},
+		City:      "Berlin",
+		Country:   "de",
+		Email:     "hostmaster@example.com",
+	}
+
+	if _, err := c.CreateContact(ctx, contact); !api.IsValidation(err) {
+		t.Errorf("CreateContact() of an invalid contact error = %v, want a validation error", err)
+	}
+
+	contact.Country = "DE"
+	contact.PostalCode = "10115"
+
+	created, err := c.CreateContact(ctx, contact)
+	if err != nil {
+		t.Fatalf("CreateContact() error = %v", err)
+	}
+
+	if created.ID == 0 {
+		t.Fatalf("CreateContact() = %+v, want a contact with an ID", created)
+	}
+
+	found, err := c.SearchContacts(ctx, []api.SearchFilter{{Key: "alias", Value: "hostmaster", Operator: "EQUAL"}})
+	if err != nil {
+		t.Fatalf("SearchContacts() error = %v", err)
+	}
+
+	if len(found) != 1 || found[0].ID != created.ID {
+		t.Errorf("SearchContacts() = %+v, want the created contact", found)
+	}
+
+	created.Email = "dns@example.com"
+	if _, err := c.UpdateContact(ctx, created); err != nil {
+		t.Fatalf("UpdateContact() error = %v", err)
+	}
+
+	s.AddDomain(api.Domain{Name: "example.com", OwnerC: &api.ContactRef{ID: created.ID}})
+
+	if err := c.DeleteContact(ctx, created.ID); !api.IsValidation(err) {
+		t.Errorf("DeleteContact() of a used contact error = %v, want a validation error", err)
+	}
+
+	s.AddDomain(api.Domain{Name: "example.com"})
+
+	if err := c.DeleteContact(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteContact() error = %v", err)
+	}
+
+	if _, err := c.GetContact(ctx, created.ID); !api.IsNotFound(err) {
+		t.Errorf("GetContact() of a deleted contact error = %v, want a not found error", err)
+	}
+}
+
+func TestServerNameServerGroups(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddDomain(api.Domain{Name: "example.com"})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	if _, err := c.CreateHost(ctx, &api.Host{Name: "ns1.example.org", IPAddresses: []string{"192.0.2.1"}}); !api.IsValidation(err) {
+		t.Errorf("CreateHost() below a foreign domain error = %v, want a validation error", err)
+	}
+
+	group := &api.NameServerGroup{
+		Name:        "example",
+		NameServers: []api.NameServer{{Name: "ns1.example.com"}, {Name: "ns2.example.com"}},
+	}
+
+	if _, err := c.CreateNameServerGroup(ctx, group); !api.IsValidation(err) {
+		t.Errorf("CreateNameServerGroup() without hosts error = %v, want a validation error", err)
+	}
+
+	for i, name := range []string{"ns1.example.com", "ns2.example.com"} {
+		_, err := c.CreateHost(ctx, &api.Host{Name: name, IPAddresses: []string{fmt.Sprintf("192.0.2.%d", i+1), "2001:db8::1"}})
+		if err != nil {
+			t.Fatalf("CreateHost() error = %v", err)
+		}
+	}
+
+	if _, err := c.CreateNameServerGroup(ctx, group); err != nil {
+		t.Fatalf("CreateNameServerGroup() error = %v", err)
+	}
+
+	// The names are escaped in the path, a query must not select another host or group
+	if _, err := c.GetHost(ctx, "ns1.example.com?name=ns2.example.com"); !api.IsNotFound(err) {
+		t.Errorf("GetHost() of a name with a query error = %v, want a not found error", err)
+	}
+
+	if _, err := c.GetNameServerGroup(ctx, group.Name+"?x"); !api.IsNotFound(err) {
+		t.Errorf("GetNameServerGroup() of a name with a query error = %v, want a not found error", err)
+	}
+
+	zone := &api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer, NameServerGroup: "missing"}
+	if _, err := c.CreateZone(ctx, zone); !api.IsValidation(err) {
+		t.Errorf("CreateZone() with a missing nameserver group error = %v, want a validation error", err)
+	}
+
+	zone.NameServerGroup = group.Name
+	if _, err := c.CreateZone(ctx, zone); err != nil {
+		t.Fatalf("CreateZone() error = %v", err)
+	}
+
+	if err := c.DeleteHost(ctx, "ns1.example.com"); !api.IsValidation(err) {
+		t.Errorf("DeleteHost() of a nameserver of a group error = %v, want a validation error", err)
+	}
+
+	if err := c.DeleteNameServerGroup(ctx, group.Name); !api.IsValidation(err) {
+		t.Errorf("DeleteNameServerGroup() of a used group error = %v, want a validation error", err)
+	}
+
+	if err := c.DeleteZone(ctx, testZoneID); err != nil {
+		t.Fatalf("DeleteZone() error = %v", err)
+	}
+
+	if err := c.DeleteNameServerGroup(ctx, group.Name); err != nil {
+		t.Fatalf("DeleteNameServerGroup() error = %v", err)
+	}
+
+	host, err := c.UpdateHost(ctx, &api.Host{Name: "ns1.example.com", IPAddresses: []string{"198.51.100.1"}})
+	if err != nil {
+		t.Fatalf("UpdateHost() error = %v", err)
+	}
+
+	if len(host.IPAddresses) != 1 || host.IPAddresses[0] != "198.51.100.1" {
+		t.Errorf("UpdateHost() = %+v, want the updated addresses", host)
+	}
+
+	if err := c.DeleteHost(ctx, "ns1.example.com"); err != nil {
+		t.Fatalf("DeleteHost() error = %v", err)
+	}
+
+	if _, err := c.GetHost(ctx, "ns1.example.com"); !api.IsNotFound(err) {
+		t.Errorf("GetHost() of a deleted host error = %v, want a not found error", err)
+	}
+}
+
+func TestServerCertificates(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	certificate := &api.Certificate{Name: "www.example.com", Product: "BASIC_SSL", CSR: testCSR(t, "example.com")}
+	if _, err := c.PrepareCertificateOrder(ctx, certificate); !api.IsValidation(err) {
+		t.Errorf("PrepareCertificateOrder() with a CSR for another name error = %v, want a validation error", err)
+	}
+
+	certificate.CSR = testCSR(t, "www.example.com", "example.com")
+
+	prepared, err := c.PrepareCertificateOrder(ctx, certificate)
+	if err != nil {
+		t.Fatalf("PrepareCertificateOrder() error = %v", err)
+	}
+
+	if len(prepared.Authentication) != 2 || prepared.Authentication[0].Name != "_dnsauth.www.example.com" {
+		t.Fatalf("PrepareCertificateOrder() = %+v, want a validation record for each domain", prepared.Authentication)
+	}
+
+	created, err := c.CreateCertificate(ctx, certificate)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+
+	if created.Status != api.CertificateStatusPending {
+		t.Errorf("CreateCertificate() status = %s, want %s", created.Status, api.CertificateStatusPending)
+	}
+
+	// Without the validation records the certificate is never issued
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.WaitForCertificate(waitCtx, created.ID); err == nil {
+		t.Errorf("WaitForCertificate() without validation records succeeded, want a timeout")
+	}
+
+	records := []api.Record{}
+	for _, v := range prepared.Authentication {
+		records = append(records, api.Record{Name: strings.TrimSuffix(v.Name, ".example.com"), Type: v.Type, TTL: 60, Value: v.Value})
+	}
+
+	if err := c.CreateRecords(ctx, testZoneID, records); err != nil {
+		t.Fatalf("CreateRecords() error = %v", err)
+	}
+
+	issued, err := c.WaitForCertificate(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("WaitForCertificate() error = %v", err)
+	}
+
+	if issued.Status != api.CertificateStatusActive || issued.Server == "" || len(issued.Chain) != 1 || issued.Expire == "" {
+		t.Errorf("WaitForCertificate() = %+v, want an issued certificate", issued)
+	}
+
+	issued.CSR = certificate.CSR
+	renewed, err := c.RenewCertificate(ctx, issued)
+	if err != nil {
+		t.Fatalf("RenewCertificate() error = %v", err)
+	}
+
+	if renewed.ID != created.ID || renewed.Status != api.CertificateStatusPending {
+		t.Errorf("RenewCertificate() = %+v, want the pending certificate with the same ID", renewed)
+	}
+
+	if err := c.DeleteCertificate(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteCertificate() error = %v", err)
+	}
+
+	if _, err := c.GetCertificate(ctx, created.ID); !api.IsNotFound(err) {
+		t.Errorf("GetCertificate() of a deleted certificate error = %v, want a not found error", err)
+	}
+}
+
+func TestServerRedirects(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	ctx := context.Background()
+
+	redirect := &api.Redirect{
+		Source: "promo.example.com",
+		Target: "example.com/landing",
+		Type:   api.RedirectTypeDomain,
+		Mode:   api.RedirectModeHTTPX,
+	}
+
+	if _, err := c.CreateRedirect(ctx, redirect); !api.IsValidation(err) {
+		t.Errorf("CreateRedirect() with a target without scheme error = %v, want a validation error", err)
+	}
+
+	redirect.Target = "https://example.com/landing"
+	redirect.ForwardQuery = true
+
+	created, err := c.CreateRedirect(ctx, redirect)
+	if err != nil {
+		t.Fatalf("CreateRedirect() error = %v", err)
+	}
+
+	if created.Domain != "example.com" || created.Created == "" {
+		t.Errorf("CreateRedirect() = %+v, want the domain of the zone", created)
+	}
+
+	if _, err := c.CreateRedirect(ctx, redirect); !api.IsValidation(err) {
+		t.Errorf("CreateRedirect() of an existing redirect error = %v, want a validation error", err)
+	}
+
+	_, err = c.CreateRedirect(ctx, &api.Redirect{Source: "info@example.com", Target: "team@example.org", Type: api.RedirectTypeEmail, Mode: api.RedirectModeSingle})
+	if err != nil {
+		t.Fatalf("CreateRedirect() of an email redirect error = %v", err)
+	}
+
+	found, err := c.SearchRedirects(ctx, []api.SearchFilter{{Key: "type", Value: api.RedirectTypeDomain, Operator: "EQUAL"}})
+	if err != nil {
+		t.Fatalf("SearchRedirects() error = %v", err)
+	}
+
+	if len(found) != 1 || found[0].Source != redirect.Source {
+		t.Errorf("SearchRedirects() = %+v, want the domain redirect", found)
+	}
+
+	created.Mode = api.RedirectModeFrame
+	created.Title = "Promo"
+	updated, err := c.UpdateRedirect(ctx, created)
+	if err != nil {
+		t.Fatalf("UpdateRedirect() error = %v", err)
+	}
+
+	if updated.Mode != api.RedirectModeFrame || updated.Title != "Promo" || updated.Created != created.Created {
+		t.Errorf("UpdateRedirect() = %+v, want the frame redirect", updated)
+	}
+
+	if err := c.DeleteRedirect(ctx, redirect.Source); err != nil {
+		t.Fatalf("DeleteRedirect() error = %v", err)
+	}
+
+	if _, err := c.GetRedirect(ctx, redirect.Source); !api.IsNotFound(err) {
+		t.Errorf("GetRedirect() of a deleted redirect error = %v, want a not found error", err)
+	}
+
+	if stored, ok := s.Redirect("info@example.com"); !ok || stored.Domain != "example.com" {
+		t.Errorf("Redirect() = %+v, %v, want the email redirect", stored, ok)
+	}
+}
+
+func TestServerChecksAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	tests := map[string]func(c *api.Client){
+		"password": func(c *api.Client) { c.Password = "wrong" },
+		"context":  func(c *api.Client) { c.Context = "1" },
+	}
+
+	for name, tamper := range tests {
+		c := s.APIClient()
+		tamper(c)
+
+		if _, err := c.GetZoneByID(context.Background(), testZoneID); !api.IsAuthFailure(err) {
+			t.Errorf("wrong %s: error = %v, want an auth failure", name, err)
+		}
+	}
+}
+
+func TestServerFailures(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	s.Fail(Failure{Method: http.MethodGet, Path: "/zone/", StatusCode: http.StatusServiceUnavailable, Times: 2})
+
+	c := s.APIClient()
+	c.DisableZoneCache = true
+
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() error = %v, want the request to be retried", err)
+	}
+
+	if got := len(s.Requests()); got != 3 {
+		t.Errorf("%d requests have been received, want 3", got)
+	}
+
+	s.Fail(Failure{StatusCode: http.StatusTooManyRequests, Code: "E0429"})
+	c.MaxRetries = 0
+
+	_, err := c.GetZoneByID(context.Background(), testZoneID)
+	if !api.IsRateLimited(err) {
+		t.Fatalf("GetZoneByID() error = %v, want rate limited", err)
+	}
+
+	s.ClearFailures()
+
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); err != nil {
+		t.Errorf("GetZoneByID() error = %v after clearing the failures", err)
+	}
+}
+
+func TestServerChecksTOTP(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.TOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() error = %v", err)
+	}
+
+	c = s.APIClient()
+	c.TOTPSecret = ""
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); !api.IsAuthFailure(err) {
+		t.Errorf("GetZoneByID() without a one-time code error = %v, want an auth failure", err)
+	}
+}
+
+func TestServerSessions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	c.AuthMode = api.AuthModeSession
+	c.DisableZoneCache = true
+
+	for range 2 {
+		if _, err := c.GetZoneByID(context.Background(), testZoneID); err != nil {
+			t.Fatalf("GetZoneByID() error = %v", err)
+		}
+	}
+
+	if got := countRequests(s, "/login"); got != 1 {
+		t.Errorf("logged in %d times, want once", got)
+	}
+
+	// The client logs in again when the session has expired
+	s.ExpireSessions()
+
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() after the session expired error = %v", err)
+	}
+
+	if got := countRequests(s, "/login"); got != 2 {
+		t.Errorf("logged in %d times, want twice", got)
+	}
+
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if s.Sessions() != 0 {
+		t.Errorf("%d sessions are still logged in", s.Sessions())
+	}
+
+	c.Password = "wrong"
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); !api.IsAuthFailure(err) {
+		t.Errorf("GetZoneByID() with a wrong password error = %v, want an auth failure", err)
+	}
+}
+
+func countRequests(s *Server, path string) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if r.Path == path {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestServerOwners(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.Owners = []api.Owner{{User: "customer", Context: "4"}, {User: "other", Context: "4"}}
+	s.AddZone(api.Zone{Origin: testZoneID.Origin, VirtualNameServer: testZoneID.VirtualNameServer})
+
+	c := s.APIClient()
+	c.Owner = api.Owner{User: "customer", Context: "4"}
+
+	if _, err := c.GetZoneByID(context.Background(), testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() error = %v", err)
+	}
+
+	// The zone cache must not be shared between owners
+	ctx := api.WithOwner(context.Background(), api.Owner{User: "other"})
+	if _, err := c.GetZoneByID(ctx, testZoneID); err != nil {
+		t.Fatalf("GetZoneByID() with another owner error = %v", err)
+	}
+
+	requests := s.Requests()
+	if len(requests) != 2 || requests[0].Owner.User != "customer" || requests[1].Owner != (api.Owner{User: "other", Context: "4"}) {
+		t.Errorf("requests = %+v, want one for every owner", requests)
+	}
+
+	ctx = api.WithOwner(context.Background(), api.Owner{User: "unknown"})
+	if _, err := c.GetZoneByID(ctx, testZoneID); !api.IsAuthFailure(err) {
+		t.Errorf("GetZoneByID() for an unknown owner error = %v, want an auth failure", err)
+	}
+}
+
+// testCSR returns a PEM encoded CSR for the domains, the first domain is the common name.
+func testCSR(t *testing.T, domains ...string) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: domains[0]},
+		DNSNames: domains,
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
@@ -0,0 +1,77 @@
+package autodnstest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"terraform-provider-autodns/internal/api"
+)
+
+// loginReq is the payload of the login request.
+type loginReq struct {
+	Context  int    `json:"context"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// Sessions returns the number of sessions which are logged in.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// ExpireSessions ends all sessions, like AutoDNS does after the session timeout.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.sessions)
+}
+
+func (s *Server) validSession(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sessions[id]
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	lr := &loginReq{}
+	if !s.decode(w, r, lr) {
+		return
+	}
+
+	if lr.User != s.Username || lr.Password != s.Password {
+		s.writeError(w, http.StatusUnauthorized, "EF00000", "Authentication error.")
+		return
+	}
+
+	if s.TOTPSecret != "" && !s.validTOTP(r.Header.Get("X-Domainrobot-2FA-Token")) {
+		s.writeError(w, http.StatusUnauthorized, "EF00000", "Two-factor authentication failed.")
+		return
+	}
+
+	if strconv.Itoa(lr.Context) != s.Context {
+		s.writeError(w, http.StatusForbidden, "EF00000", "The user is not assigned to the requested context.")
+		return
+	}
+
+	id := fmt.Sprintf("session-%s", s.nextSTID())
+
+	s.mu.Lock()
+	s.sessions[id] = true
+	s.mu.Unlock()
+
+	w.Header().Set(api.SessionHeader, id)
+	s.writeData(w, "S0101", "Login successful.")
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	delete(s.sessions, r.Header.Get(api.SessionHeader))
+	s.mu.Unlock()
+
+	s.writeData(w, "S0102", "Logout successful.")
+}
@@ -0,0 +1,413 @@
+package autodnstest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"time"
+)
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	zone := &api.Zone{}
+	if !s.decode(w, r, zone) {
+		return
+	}
+
+	if zone.Origin == "" || zone.VirtualNameServer == "" {
+		s.writeError(w, http.StatusBadRequest, "EF02023", "The origin and the virtual name server of the zone are required.")
+		return
+	}
+
+	if msgs := append(validateRecords(zone.Records), validateZoneTransfer(zone)...); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF02020", "The zone data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	_, exists := s.zones[zone.ID()]
+	validGroup := s.validNameServerGroup(zone)
+	if !exists && validGroup {
+		zone = defaultZone(zone)
+		zone.DNSSECKeys = dnssecKeys(zone.DNSSEC, nil)
+		zone.Created = now()
+		zone.Updated = zone.Created
+		s.zones[zone.ID()] = zone
+		zone = copyZone(zone)
+	}
+	s.mu.Unlock()
+
+	if exists {
+		s.writeError(w, http.StatusBadRequest, "EF02021", "The zone already exists.", api.Message{
+			Text:    "A zone with the origin already exists on the virtual name server.",
+			Code:    "EF02021",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "zone", Value: zone.ID().String()}},
+		})
+		return
+	}
+
+	if !validGroup {
+		s.writeNameServerGroupInvalid(w, zone)
+		return
+	}
+
+	s.writeData(w, "S0201", "Zone created successfully.", zone)
+}
+
+func (s *Server) searchZones(w http.ResponseWriter, r *http.Request) {
+	zf := &api.SearchFilterReq{}
+	if !s.decode(w, r, zf) {
+		return
+	}
+
+	s.mu.Lock()
+	zones := []any{}
+	for _, zone := range s.zones {
+		if matchZone(zone, zf.Filters) {
+			// AutoDNS doesn't include the records in search results
+			z := copyZone(zone)
+			z.Records = nil
+			zones = append(zones, z)
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(zones, func(a, b any) int {
+		return strings.Compare(a.(*api.Zone).ID().String(), b.(*api.Zone).ID().String())
+	})
+
+	// Searches without a view are truncated
+	view := api.View{Limit: s.SearchLimit}
+	if zf.View != nil {
+		view = *zf.View
+	}
+
+	total := len(zones)
+	zones = zones[min(view.Offset, len(zones)):]
+	zones = zones[:min(view.Limit, len(zones))]
+
+	s.writeSearchData(w, "S0205", "Zones searched successfully.", "Zone", total, zones...)
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	zoneID, ok := s.zoneID(r)
+	var zone *api.Zone
+	if ok {
+		zone = copyZone(s.zones[zoneID])
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeZoneNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0205", "Zone information inquired successfully.", zone)
+}
+
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request) {
+	zone := &api.Zone{}
+	if !s.decode(w, r, zone) {
+		return
+	}
+
+	if msgs := append(validateRecords(zone.Records), validateZoneTransfer(zone)...); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF02020", "The zone data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	zoneID, ok := s.zoneID(r)
+	validGroup := true
+	if ok {
+		// The zone is addressed by the path, the payload can't move it
+		zone.Origin = zoneID.Origin
+		zone.VirtualNameServer = zoneID.VirtualNameServer
+		validGroup = s.validNameServerGroup(zone)
+	}
+	if ok && validGroup {
+		zone.Created = s.zones[zoneID].Created
+		zone.Updated = now()
+		// The keys are generated by the API, the payload can't change them
+		zone.DNSSECKeys = dnssecKeys(zone.DNSSEC, s.zones[zoneID].DNSSECKeys)
+		zone = defaultZone(zone)
+		s.zones[zoneID] = zone
+		zone = copyZone(zone)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeZoneNotFound(w, r)
+		return
+	}
+
+	if !validGroup {
+		s.writeNameServerGroupInvalid(w, zone)
+		return
+	}
+
+	s.writeData(w, "S0202", "Zone updated successfully.", zone)
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	zoneID, ok := s.zoneID(r)
+	delete(s.zones, zoneID)
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeZoneNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0203", "Zone deleted successfully.")
+}
+
+func (s *Server) rolloverKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	zoneID, ok := s.zoneID(r)
+	var zone *api.Zone
+	if ok {
+		zone = s.zones[zoneID]
+		if zone.DNSSEC {
+			zone.DNSSECKeys = dnssecKeys(true, nil)
+			zone.Updated = now()
+		}
+		zone = copyZone(zone)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeZoneNotFound(w, r)
+		return
+	}
+
+	if !zone.DNSSEC {
+		s.writeError(w, http.StatusBadRequest, "EF02024", "DNSSEC is not enabled for the zone.")
+		return
+	}
+
+	s.writeData(w, "S0202", "Zone keys rolled over successfully.", zone)
+}
+
+func (s *Server) streamZone(w http.ResponseWriter, r *http.Request) {
+	zs := &api.ZoneStream{}
+	if !s.decode(w, r, zs) {
+		return
+	}
+
+	if msgs := validateRecords(zs.Adds); len(msgs) != 0 {
+		s.writeError(w, http.StatusBadRequest, "EF02020", "The zone data is invalid.", msgs...)
+		return
+	}
+
+	s.mu.Lock()
+	zoneID, ok := s.zoneID(r)
+	var zone *api.Zone
+	if ok {
+		zone = s.zones[zoneID]
+		zone.Records = slices.DeleteFunc(zone.Records, func(rec api.Record) bool {
+			return slices.ContainsFunc(zs.Rems, rec.Equal)
+		})
+
+		for _, rec := range zs.Adds {
+			if !slices.ContainsFunc(zone.Records, rec.Equal) {
+				zone.Records = append(zone.Records, rec)
+			}
+		}
+		zone.Updated = now()
+
+		zone = copyZone(zone)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeZoneNotFound(w, r)
+		return
+	}
+
+	s.writeData(w, "S0202", "Zone updated successfully.", zone)
+}
+
+// dnssecKeys returns the keys of a zone, new ones are generated when signing is enabled without keys.
+func dnssecKeys(enabled bool, keys []api.DNSKey) []api.DNSKey {
+	if !enabled {
+		return nil
+	}
+
+	if len(keys) != 0 {
+		return keys
+	}
+
+	// A key signing and a zone signing key with ECDSA P-256, like AutoDNS creates them
+	return []api.DNSKey{
+		{Flags: api.DNSKeyFlagsKSK, Protocol: 3, Algorithm: 13, PublicKey: randomKey()},
+		{Flags: 256, Protocol: 3, Algorithm: 13, PublicKey: randomKey()},
+	}
+}
+
+func randomKey() string {
+	key := make([]byte, 64)
+	_, _ = rand.Read(key)
+
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// now returns the current time in the format of the AutoDNS timestamps.
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000-0700")
+}
+
+// matchZone reports whether the zone matches the filters.
+func matchZone(zone *api.Zone, filters []api.SearchFilter) bool {
+	return matchFilters(filters, func(key string) (string, bool) {
+		switch key {
+		case "origin":
+			return zone.Origin, true
+		case "virtualNameServer":
+			return zone.VirtualNameServer, true
+		case "nameServerGroup":
+			return zone.NameServerGroup, true
+		case "created":
+			return zone.Created, true
+		case "updated":
+			return zone.Updated, true
+		default:
+			return "", false
+		}
+	})
+}
+
+// matchFilters reports whether the object with the fields matches the filters, unknown keys never match.
+// The filters are evaluated in order, every filter is combined with the following one by its link.
+func matchFilters(filters []api.SearchFilter, field func(key string) (string, bool)) bool {
+	match := true
+	link := "AND"
+
+	for _, f := range filters {
+		m := matchFilter(f, field)
+		if link == "OR" {
+			match = match || m
+		} else {
+			match = match && m
+		}
+
+		link = f.Link
+	}
+
+	return match
+}
+
+// matchFilter reports whether the object matches a single filter, or its nested filters for groups.
+func matchFilter(f api.SearchFilter, field func(key string) (string, bool)) bool {
+	if f.Key == "" {
+		return matchFilters(f.Filters, field)
+	}
+
+	v, ok := field(f.Key)
+	if !ok {
+		return false
+	}
+
+	return matchValue(v, f.Value, f.Operator)
+}
+
+// matchValue compares the value with the filter value using the filter operator.
+func matchValue(v, filter, operator string) bool {
+	switch operator {
+	case "", "EQUAL":
+		return v == filter
+	case "NOT_EQUAL":
+		return v != filter
+	case "LIKE":
+		// AutoDNS uses * as the wildcard of LIKE filters
+		ok, _ := path.Match(filter, v)
+		return ok
+	case "NOT_LIKE":
+		ok, _ := path.Match(filter, v)
+		return !ok
+	case "GREATER":
+		return v > filter
+	case "GREATER_EQUAL":
+		return v >= filter
+	case "LESS":
+		return v < filter
+	case "LESS_EQUAL":
+		return v <= filter
+	}
+
+	return false
+}
+
+// validateRecords returns a message for every record AutoDNS would reject.
+func validateRecords(records []api.Record) []api.Message {
+	msgs := []api.Message{}
+
+	for _, rec := range records {
+		var text string
+		switch {
+		case rec.Type == "":
+			text = "The record type is missing."
+		case rec.Value == "":
+			text = "The record value is missing."
+		case rec.Type == "A" && (net.ParseIP(rec.Value) == nil || net.ParseIP(rec.Value).To4() == nil):
+			text = "The value of an A record must be an IPv4 address."
+		case rec.Type == "AAAA" && (net.ParseIP(rec.Value) == nil || net.ParseIP(rec.Value).To4() != nil):
+			text = "The value of an AAAA record must be an IPv6 address."
+		default:
+			continue
+		}
+
+		msgs = append(msgs, api.Message{
+			Text:    text,
+			Code:    "EF02020",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "record", Value: rec.Name + " " + rec.Type + " " + rec.Value}},
+		})
+	}
+
+	return msgs
+}
+
+// validateZoneTransfer returns a message for every transfer setting of the zone AutoDNS would reject.
+func validateZoneTransfer(zone *api.Zone) []api.Message {
+	msgs := []api.Message{}
+
+	addMsg := func(text, value string) {
+		msgs = append(msgs, api.Message{
+			Text:    text,
+			Code:    "EF02025",
+			Status:  "ERROR",
+			Objects: []api.MessageObject{{Type: "zone", Value: value}},
+		})
+	}
+
+	switch zone.Type {
+	case "", api.ZoneTypePrimary:
+		if len(zone.Primaries) != 0 {
+			addMsg("Only secondary zones can have primary nameservers.", zone.Origin)
+		}
+	case api.ZoneTypeSecondary:
+		if len(zone.Primaries) == 0 {
+			addMsg("Secondary zones require at least one primary nameserver.", zone.Origin)
+		}
+	default:
+		addMsg("The zone type must be PRIMARY or SECONDARY.", zone.Origin)
+	}
+
+	for _, addresses := range [][]string{zone.Primaries, zone.Grants, zone.Notifies} {
+		for _, address := range addresses {
+			if net.ParseIP(address) == nil {
+				addMsg("The transfer address must be an IP address: "+address, zone.Origin)
+			}
+		}
+	}
+
+	return msgs
+}
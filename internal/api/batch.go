@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultBatchWindow is how long stream writes are collected before they are sent together.
+const DefaultBatchWindow = 200 * time.Millisecond
+
+// streamBatcher coalesces the stream writes to the same zone into a single API request.
+type streamBatcher struct {
+	mu      sync.Mutex
+	pending map[zoneKey]*streamBatch
+}
+
+// streamBatch collects the stream writes to a zone until it's flushed.
+type streamBatch struct {
+	writes []*streamWrite
+}
+
+// streamWrite is the stream payload of a single caller, waiting for its result.
+type streamWrite struct {
+	zs   ZoneStream
+	done chan error
+}
+
+// stream queues the stream payload for the zone and waits until it has been sent.
+// A write canceled by the context before its batch is sent is dropped from the batch.
+// Payloads queued for the same zone and owner within the batch window are sent in one request,
+// which results in a single serial increment of the zone.
+func (c *Client) stream(ctx context.Context, zoneID ZoneID, zs ZoneStream) error {
+	if c.BatchWindow <= 0 {
+		return c.sendStream(ctx, zoneID, zs)
+	}
+
+	w := &streamWrite{zs: zs, done: make(chan error, 1)}
+	key := c.zoneKey(ctx, zoneID)
+
+	sb := &c.streamBatcher
+	sb.mu.Lock()
+	if sb.pending == nil {
+		sb.pending = map[zoneKey]*streamBatch{}
+	}
+
+	b, ok := sb.pending[key]
+	if !ok {
+		b = &streamBatch{}
+		sb.pending[key] = b
+
+		// The batch outlives the caller starting it, so it must not be canceled with it
+		flushCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(c.BatchWindow, func() { c.flushStreams(flushCtx, key) })
+	}
+	b.writes = append(b.writes, w)
+	sb.mu.Unlock()
+
+	select {
+	case err := <-w.done:
+		return err
+	case <-ctx.Done():
+	}
+
+	// A canceled write is dropped while it's still pending, once the batch is sent its result must be awaited,
+	// so the caller doesn't report a failure for records which have been written
+	sb.mu.Lock()
+	if sb.pending[key] == b {
+		b.writes = slices.DeleteFunc(b.writes, func(pending *streamWrite) bool { return pending == w })
+		sb.mu.Unlock()
+
+		return ctx.Err()
+	}
+	sb.mu.Unlock()
+
+	return <-w.done
+}
+
+// flushStreams sends the pending batch of the zone and reports the result to every caller.
+func (c *Client) flushStreams(ctx context.Context, key zoneKey) {
+	zoneID := key.ZoneID
+
+	sb := &c.streamBatcher
+	sb.mu.Lock()
+	b := sb.pending[key]
+	delete(sb.pending, key)
+	sb.mu.Unlock()
+
+	// Every write of the batch has been canceled
+	if len(b.writes) == 0 {
+		return
+	}
+
+	merged := ZoneStream{
+		Adds: []Record{},
+		Rems: []Record{},
+	}
+
+	for _, w := range b.writes {
+		for _, r := range w.zs.Adds {
+			if !slices.ContainsFunc(merged.Adds, r.Equal) {
+				merged.Adds = append(merged.Adds, r)
+			}
+		}
+
+		for _, r := range w.zs.Rems {
+			if !slices.ContainsFunc(merged.Rems, r.Equal) {
+				merged.Rems = append(merged.Rems, r)
+			}
+		}
+	}
+
+	err := c.sendStream(ctx, zoneID, merged)
+	if err == nil || len(b.writes) == 1 || !IsValidation(err) {
+		for _, w := range b.writes {
+			w.done <- err
+		}
+
+		return
+	}
+
+	// The API rejected some of the records, so every caller gets the result of its own records
+	for _, w := range b.writes {
+		w.done <- c.sendStream(ctx, zoneID, w.zs)
+	}
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testBatchZoneID = ZoneID{Origin: "example.com", VirtualNameServer: "a.ns14.net"}
+
+// streamRequest is a stream received by the test server.
+type streamRequest struct {
+	ownerUser string
+	stream    ZoneStream
+}
+
+// newBatchTestClient returns a client for a server recording the streams it receives.
+// Streams adding records with the value "invalid" are rejected.
+func newBatchTestClient(t *testing.T) (*Client, func() []streamRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	streams := []streamRequest{}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		zs := ZoneStream{}
+		if err := json.NewDecoder(r.Body).Decode(&zs); err != nil {
+			t.Errorf("invalid stream payload: %v", err)
+		}
+
+		mu.Lock()
+		streams = append(streams, streamRequest{ownerUser: r.Header.Get("X-Domainrobot-Owner-User"), stream: zs})
+		mu.Unlock()
+
+		if slices.ContainsFunc(zs.Adds, func(r Record) bool { return r.Value == "invalid" }) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"status":{"code":"EF02020","text":"The zone data is invalid.","type":"ERROR"}}`)
+			return
+		}
+
+		io.WriteString(w, `{"data":[]}`)
+	})
+	c.BatchWindow = 20 * time.Millisecond
+
+	return c, func() []streamRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Clone(streams)
+	}
+}
+
+// concurrently runs the writes in parallel and returns their errors in order.
+func concurrently(writes ...func() error) []error {
+	errs := make([]error, len(writes))
+
+	var wg sync.WaitGroup
+	for i, write := range writes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = write()
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func TestStreamBatchMerges(t *testing.T) {
+	c, streams := newBatchTestClient(t)
+	ctx := context.Background()
+
+	www := Record{Name: "www", Type: "A", TTL: 60, Value: "192.0.2.1"}
+	mail := Record{Name: "mail", Type: "A", TTL: 60, Value: "192.0.2.2"}
+	old := Record{Name: "old", Type: "A", TTL: 60, Value: "192.0.2.3"}
+
+	errs := concurrently(
+		func() error { return c.CreateRecords(ctx, testBatchZoneID, []Record{www}) },
+		func() error { return c.CreateRecords(ctx, testBatchZoneID, []Record{www, mail}) },
+		func() error { return c.DeleteRecords(ctx, testBatchZoneID, []Record{old}) },
+		func() error { return c.DeleteRecords(ctx, testBatchZoneID, []Record{old}) },
+	)
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("stream error = %v", err)
+		}
+	}
+
+	got := streams()
+	if len(got) != 1 {
+		t.Fatalf("%d streams have been sent, want 1", len(got))
+	}
+
+	if len(got[0].stream.Adds) != 2 || len(got[0].stream.Rems) != 1 {
+		t.Errorf("stream = %+v, want the deduplicated adds and removals of every write", got[0].stream)
+	}
+}
+
+func TestStreamBatchPerOwner(t *testing.T) {
+	c, streams := newBatchTestClient(t)
+
+	errs := concurrently(
+		func() error {
+			return c.CreateRecords(WithOwner(context.Background(), Owner{User: "alice"}), testBatchZoneID, []Record{{Name: "a", Type: "A", Value: "192.0.2.1"}})
+		},
+		func() error {
+			return c.CreateRecords(WithOwner(context.Background(), Owner{User: "bob"}), testBatchZoneID, []Record{{Name: "b", Type: "A", Value: "192.0.2.2"}})
+		},
+	)
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("stream error = %v", err)
+		}
+	}
+
+	owners := []string{}
+	for _, s := range streams() {
+		if len(s.stream.Adds) != 1 {
+			t.Errorf("stream of %s = %+v, want only the record of the owner", s.ownerUser, s.stream)
+		}
+		owners = append(owners, s.ownerUser)
+	}
+
+	slices.Sort(owners)
+	if strings.Join(owners, ",") != "alice,bob" {
+		t.Errorf("streams have been sent for %v, want one for every owner", owners)
+	}
+}
+
+func TestStreamBatchFallsBackPerWrite(t *testing.T) {
+	c, streams := newBatchTestClient(t)
+	ctx := context.Background()
+
+	errs := concurrently(
+		func() error {
+			return c.CreateRecords(ctx, testBatchZoneID, []Record{{Name: "www", Type: "A", Value: "192.0.2.1"}})
+		},
+		func() error {
+			return c.CreateRecords(ctx, testBatchZoneID, []Record{{Name: "bad", Type: "A", Value: "invalid"}})
+		},
+	)
+
+	if errs[0] != nil {
+		t.Errorf("valid write error = %v", errs[0])
+	}
+
+	if !IsValidation(errs[1]) {
+		t.Errorf("invalid write error = %v, want a validation error", errs[1])
+	}
+
+	// The merged stream and one stream for each write
+	if got := len(streams()); got != 3 {
+		t.Errorf("%d streams have been sent, want 3", got)
+	}
+}
+
+func TestStreamBatchDropsCanceledWrite(t *testing.T) {
+	c, streams := newBatchTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- c.CreateRecords(ctx, testBatchZoneID, []Record{{Name: "canceled", Type: "A", Value: "192.0.2.1"}})
+	}()
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	err := c.CreateRecords(context.Background(), testBatchZoneID, []Record{{Name: "www", Type: "A", Value: "192.0.2.2"}})
+	if err != nil {
+		t.Fatalf("CreateRecords() error = %v", err)
+	}
+
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled CreateRecords() error = %v, want context.Canceled", err)
+	}
+
+	got := streams()
+	if len(got) != 1 || len(got[0].stream.Adds) != 1 || got[0].stream.Adds[0].Name != "www" {
+		t.Errorf("streams = %+v, want only the write which hasn't been canceled", got)
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// zoneCache keeps the zones fetched by the client, so resources in the same zone share a single request.
+type zoneCache struct {
+	mu          sync.Mutex
+	entries     map[zoneKey]*zoneCacheEntry
+	generations map[ZoneID]uint64
+}
+
+// zoneCacheEntry is a cached zone, or a fetch in flight until done is closed.
+type zoneCacheEntry struct {
+	done chan struct{}
+	zone *Zone
+	err  error
+}
+
+// cachedZone returns the cached zone or fetches it, making sure concurrent callers share the same fetch.
+func (c *Client) cachedZone(ctx context.Context, zoneID ZoneID, fetch func() (*Zone, error)) (*Zone, error) {
+	if c.DisableZoneCache {
+		return fetch()
+	}
+
+	key := c.zoneKey(ctx, zoneID)
+
+	zc := &c.zoneCache
+	for {
+		zc.mu.Lock()
+		if zc.entries == nil {
+			zc.entries = map[zoneKey]*zoneCacheEntry{}
+			zc.generations = map[ZoneID]uint64{}
+		}
+
+		e, ok := zc.entries[key]
+		if !ok {
+			break
+		}
+		zc.mu.Unlock()
+
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// The fetch was abandoned by the caller who started it, which doesn't concern this caller
+		if errors.Is(e.err, context.Canceled) || errors.Is(e.err, context.DeadlineExceeded) {
+			continue
+		}
+
+		if e.err != nil {
+			return nil, e.err
+		}
+
+		return e.zone.clone(), nil
+	}
+
+	e := &zoneCacheEntry{done: make(chan struct{})}
+	zc.entries[key] = e
+	generation := zc.generations[zoneID]
+	zc.mu.Unlock()
+
+	zone, err := fetch()
+
+	zc.mu.Lock()
+	e.zone, e.err = zone, err
+
+	// Failed fetches and zones written to in the meantime must not be served from the cache
+	if (err != nil || zc.generations[zoneID] != generation) && zc.entries[key] == e {
+		delete(zc.entries, key)
+	}
+	close(e.done)
+	zc.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return zone.clone(), nil
+}
+
+// invalidateZone drops the zone of every owner from the cache, it must be called for every write to the zone.
+func (c *Client) invalidateZone(zoneID ZoneID) {
+	zc := &c.zoneCache
+	zc.mu.Lock()
+	defer zc.mu.Unlock()
+
+	if zc.entries == nil {
+		return
+	}
+
+	zc.generations[zoneID]++
+	for key := range zc.entries {
+		if key.ZoneID == zoneID {
+			delete(zc.entries, key)
+		}
+	}
+}
+
+// clone returns a deep copy of the zone, so callers can't modify the cached one.
+func (z *Zone) clone() *Zone {
+	c := *z
+
+	if z.SOA != nil {
+		soa := *z.SOA
+		c.SOA = &soa
+	}
+
+	if z.Main != nil {
+		main := *z.Main
+		c.Main = &main
+	}
+
+	c.NameServers = slices.Clone(z.NameServers)
+	for i := range c.NameServers {
+		c.NameServers[i].IPAddresses = slices.Clone(c.NameServers[i].IPAddresses)
+	}
+
+	c.Records = slices.Clone(z.Records)
+	c.DNSSECKeys = slices.Clone(z.DNSSECKeys)
+	c.Primaries = slices.Clone(z.Primaries)
+	c.Grants = slices.Clone(z.Grants)
+	c.Notifies = slices.Clone(z.Notifies)
+
+	return &c
+}
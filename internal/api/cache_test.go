@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestZoneClone(t *testing.T) {
+	zone := &Zone{
+		Origin:      "example.com",
+		SOA:         &SOA{TTL: 60},
+		NameServers: []NameServer{{Name: "a.ns14.net", IPAddresses: []string{"192.0.2.1"}}},
+		Records:     []Record{{Name: "www", Type: "A", Value: "192.0.2.1"}},
+		DNSSECKeys:  []DNSKey{{Flags: DNSKeyFlagsKSK}},
+		ZoneTransfer: ZoneTransfer{
+			Type:      ZoneTypeSecondary,
+			Primaries: []string{"192.0.2.1"},
+			Grants:    []string{"192.0.2.2"},
+			Notifies:  []string{"192.0.2.3"},
+		},
+	}
+
+	c := zone.clone()
+	c.SOA.TTL = 120
+	c.NameServers[0].IPAddresses[0] = "changed"
+	c.Records[0].Value = "changed"
+	c.DNSSECKeys[0].Flags = 0
+	c.Primaries[0] = "changed"
+	c.Grants[0] = "changed"
+	c.Notifies[0] = "changed"
+
+	if zone.SOA.TTL != 60 || zone.NameServers[0].IPAddresses[0] != "192.0.2.1" || zone.Records[0].Value != "192.0.2.1" ||
+		zone.DNSSECKeys[0].Flags != DNSKeyFlagsKSK || zone.Primaries[0] != "192.0.2.1" || zone.Grants[0] != "192.0.2.2" ||
+		zone.Notifies[0] != "192.0.2.3" {
+		t.Errorf("changing the clone changed the zone: %+v", zone)
+	}
+}
+
+var testCacheZoneID = ZoneID{Origin: "example.com", VirtualNameServer: "a.ns14.net"}
+
+// newCacheTestClient returns a client for a server with the test zone, counting the zone reads.
+// The reads wait for the release function, unless it's nil.
+func newCacheTestClient(t *testing.T, release func(r *http.Request)) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var reads atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			reads.Add(1)
+
+			if release != nil {
+				release(r)
+			}
+		}
+
+		if r.Method == "GET" || r.Method == "PUT" {
+			io.WriteString(w, `{"data":[{"origin":"example.com","virtualNameServer":"a.ns14.net"}]}`)
+			return
+		}
+
+		io.WriteString(w, `{"data":[]}`)
+	})
+	c.BatchWindow = 0
+
+	return c, &reads
+}
+
+func TestZoneCacheSingleFlight(t *testing.T) {
+	c, reads := newCacheTestClient(t, func(r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.GetZoneByID(context.Background(), testCacheZoneID); err != nil {
+				t.Errorf("GetZoneByID() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := reads.Load(); got != 1 {
+		t.Errorf("the zone was read %d times, want 1", got)
+	}
+}
+
+func TestZoneCacheDropsRacingFetch(t *testing.T) {
+	var first atomic.Bool
+	fetching, release := make(chan struct{}), make(chan struct{})
+	c, reads := newCacheTestClient(t, func(r *http.Request) {
+		if first.CompareAndSwap(false, true) {
+			close(fetching)
+			<-release
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.GetZoneByID(context.Background(), testCacheZoneID)
+	}()
+
+	// The zone is written while it's fetched, so the fetched zone may be stale
+	<-fetching
+	c.invalidateZone(testCacheZoneID)
+	close(release)
+	<-done
+
+	if _, err := c.GetZoneByID(context.Background(), testCacheZoneID); err != nil {
+		t.Fatalf("GetZoneByID() error = %v", err)
+	}
+
+	if got := reads.Load(); got != 2 {
+		t.Errorf("the zone was read %d times, want 2", got)
+	}
+}
+
+func TestZoneCacheInvalidation(t *testing.T) {
+	c, reads := newCacheTestClient(t, nil)
+	ctx := context.Background()
+
+	writes := map[string]func() error{
+		"stream": func() error {
+			return c.CreateRecords(ctx, testCacheZoneID, []Record{{Name: "www", Type: "A", Value: "192.0.2.1"}})
+		},
+		"update": func() error {
+			_, err := c.UpdateZone(ctx, &Zone{Origin: testCacheZoneID.Origin, VirtualNameServer: testCacheZoneID.VirtualNameServer})
+			return err
+		},
+		"delete": func() error {
+			return c.DeleteZone(ctx, testCacheZoneID)
+		},
+	}
+
+	for name, write := range writes {
+		for range 2 {
+			if _, err := c.GetZoneByID(ctx, testCacheZoneID); err != nil {
+				t.Fatalf("GetZoneByID() error = %v", err)
+			}
+		}
+
+		before := reads.Load()
+		if err := write(); err != nil {
+			t.Fatalf("%s error = %v", name, err)
+		}
+
+		if _, err := c.GetZoneByID(ctx, testCacheZoneID); err != nil {
+			t.Fatalf("GetZoneByID() error = %v", err)
+		}
+
+		if got := reads.Load(); got != before+1 {
+			t.Errorf("the zone was served from the cache after the %s", name)
+		}
+	}
+}
+
+func TestZoneCacheDisabled(t *testing.T) {
+	c, reads := newCacheTestClient(t, nil)
+	c.DisableZoneCache = true
+
+	for range 2 {
+		if _, err := c.GetZoneByID(context.Background(), testCacheZoneID); err != nil {
+			t.Fatalf("GetZoneByID() error = %v", err)
+		}
+	}
+
+	if got := reads.Load(); got != 2 {
+		t.Errorf("the zone was read %d times, want 2", got)
+	}
+}
+
+func TestZoneCacheWaiterOutlivesCanceledFetch(t *testing.T) {
+	var first atomic.Bool
+	fetching := make(chan struct{})
+	c, reads := newCacheTestClient(t, func(r *http.Request) {
+		// The first read hangs until its caller gives up
+		if first.CompareAndSwap(false, true) {
+			close(fetching)
+			<-r.Context().Done()
+		}
+	})
+	c.MaxRetries = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go c.GetZoneByID(ctx, testCacheZoneID)
+	<-fetching
+
+	result := make(chan error)
+	go func() {
+		_, err := c.GetZoneByID(context.Background(), testCacheZoneID)
+		result <- err
+	}()
+
+	// Give the second caller the time to wait for the first fetch
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-result; err != nil {
+		t.Errorf("GetZoneByID() waiting for a canceled fetch error = %v", err)
+	}
+
+	if got := reads.Load(); got != 2 {
+		t.Errorf("the zone was read %d times, want 2", got)
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Values of the status of a certificate.
+const (
+	// CertificateStatusPending certificates wait for the validation of their domains.
+	CertificateStatusPending = "PENDING"
+	// CertificateStatusActive certificates have been issued.
+	CertificateStatusActive = "ACTIVE"
+	// CertificateStatusFailed certificates have been rejected by the certificate authority.
+	CertificateStatusFailed = "FAILED"
+)
+
+// DomainValidation is a DNS record AutoDNS looks up to validate the control over a domain of the certificate.
+type DomainValidation struct {
+	Domain string `json:"domain"`
+	// Name is the fully qualified name of the record, without a trailing dot.
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Certificate describes the certificate object in the autodns API response.
+type Certificate struct {
+	// ID is assigned by the API when the certificate is ordered.
+	ID int64 `json:"id,omitempty"`
+
+	// Name is the common name of the certificate.
+	Name                    string   `json:"name"`
+	Product                 string   `json:"product"`
+	CSR                     string   `json:"csr"`
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames,omitempty"`
+
+	// Authentication are the DNS records required to validate the domains of the certificate.
+	Authentication []DomainValidation `json:"authentication,omitempty"`
+
+	// Status, the issued certificate and its chain are set by the API, they're ignored in requests.
+	Status       string   `json:"status,omitempty"`
+	Server       string   `json:"server,omitempty"`
+	Chain        []string `json:"chain,omitempty"`
+	SerialNumber string   `json:"serialNumber,omitempty"`
+	Expire       string   `json:"expire,omitempty"`
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// PrepareCertificateOrder returns the certificate with the DNS records required to validate its domains.
+// The records must exist before the certificate is ordered, so it can be issued right away.
+func (c *Client) PrepareCertificateOrder(ctx context.Context, certificate *Certificate) (*Certificate, error) {
+	return c.writeCertificate(ctx, "POST", c.HostURL+"/certificate/_prepareOrder", certificate)
+}
+
+// CreateCertificate sends an API request to order the certificate.
+// The returned certificate is pending until its domains have been validated, see WaitForCertificate.
+func (c *Client) CreateCertificate(ctx context.Context, certificate *Certificate) (*Certificate, error) {
+	return c.writeCertificate(ctx, "POST", c.HostURL+"/certificate", certificate)
+}
+
+// RenewCertificate sends an API request to renew the certificate, optionally with a new CSR.
+// The renewed certificate keeps its ID and is pending like a new order.
+func (c *Client) RenewCertificate(ctx context.Context, certificate *Certificate) (*Certificate, error) {
+	return c.writeCertificate(ctx, "POST", fmt.Sprintf("%s/certificate/%d/_renew", c.HostURL, certificate.ID), certificate)
+}
+
+// GetCertificate returns the certificate with the ID.
+func (c *Client) GetCertificate(ctx context.Context, id int64) (*Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/certificate/%d", c.HostURL, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Certificate](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("certificate does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// DeleteCertificate sends an API request to revoke and delete the certificate.
+func (c *Client) DeleteCertificate(ctx context.Context, id int64) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/certificate/%d", c.HostURL, id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = request[any](c, req)
+
+	return err
+}
+
+// WaitForCertificate polls the certificate until it's no longer pending, with the backoff of the retries between the requests.
+// It gives up when the context is done, the context deadline is the timeout for the issuance.
+func (c *Client) WaitForCertificate(ctx context.Context, id int64) (*Certificate, error) {
+	for attempt := 0; ; attempt++ {
+		certificate, err := c.GetCertificate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch certificate.Status {
+		case CertificateStatusActive:
+			return certificate, nil
+		case CertificateStatusFailed:
+			return nil, fmt.Errorf("certificate %d has been rejected by the certificate authority", id)
+		}
+
+		timer := time.NewTimer(c.backoff(attempt, nil))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("certificate %d has not been issued in time: %w", id, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) writeCertificate(ctx context.Context, method, url string, certificate *Certificate) (*Certificate, error) {
+	b, err := json.Marshal(certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Certificate](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of certificates returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
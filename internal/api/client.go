@@ -2,76 +2,166 @@ package api
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
-	"sync"
+	"strings"
 	"time"
 )
 
 // APIResponse describes the wrapper autodns uses for their API response.
 type APIResponse[T any] struct {
-	Data []T `json:"data"`
+	Object *ResponseObject `json:"object,omitempty"`
+	Data   []T             `json:"data"`
+}
+
+// ResponseObject describes the object autodns adds to API responses.
+// For searches the summary is the total number of results, not only the ones of the returned page.
+type ResponseObject struct {
+	Type    string `json:"type"`
+	Value   string `json:"value,omitempty"`
+	Summary int    `json:"summary"`
 }
 
 // Client provides an api client implementation for the AutoDNS API.
 type Client struct {
 	HTTPClient *http.Client
 
-	mu sync.Mutex
+	scheduler scheduler
+	zoneCache zoneCache
+
+	streamBatcher streamBatcher
 
 	HostURL  string
 	Context  string
 	Username string
 	Password string
+
+	// TOTPSecret is the base32 encoded secret of accounts with two-factor authentication.
+	// A one-time code is generated from it for every request, or for every login when sessions are used.
+	TOTPSecret string
+
+	// Owner is the subuser the requests are sent on behalf of, unless the request context selects another one.
+	Owner Owner
+
+	// AuthMode is either AuthModeBasic or AuthModeSession.
+	AuthMode string
+	session  session
+
+	// MaxRetries is the number of times a request is retried on transient failures.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// MaxConcurrentRequests bounds the number of requests sent to the API in parallel.
+	MaxConcurrentRequests int
+
+	// DisableZoneCache makes every read fetch the zone from the API.
+	DisableZoneCache bool
+
+	// BatchWindow is how long record writes to a zone are collected to be sent in one request.
+	// Batching is disabled when it's zero.
+	BatchWindow time.Duration
 }
 
 // NewClient returns a new instance of the client.
+// The host is reached via https, unless it already starts with a scheme.
 func NewClient(host, context, username, password string) *Client {
+	hostURL := host
+	if !strings.Contains(host, "://") {
+		hostURL = "https://" + host
+	}
+
 	return &Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		HostURL:    "https://" + host,
-		Username:   username,
-		Password:   password,
-		Context:    context,
+		HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+		HostURL:      hostURL,
+		Username:     username,
+		Password:     password,
+		Context:      context,
+		AuthMode:     AuthModeBasic,
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
+
+		MaxConcurrentRequests: DefaultMaxConcurrentRequests,
+		BatchWindow:           DefaultBatchWindow,
 	}
 }
 
 func request[T any](c *Client, req *http.Request) ([]T, error) {
-	// Lock the mutex to avoid concurrent updates
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	resp, err := requestResponse[T](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
 
-	// Add authentication header
-	req.SetBasicAuth(c.Username, c.Password)
+// requestResponse sends the request and returns the whole API response, including the response object.
+func requestResponse[T any](c *Client, req *http.Request) (*APIResponse[T], error) {
+	// Send the request, retrying on transient failures
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshel the api response into the proper struct
+	resp := &APIResponse[T]{}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// send sends a single attempt of the request and returns the body of a 200 ok response.
+func (c *Client) send(req *http.Request) ([]byte, *http.Response, error) {
+	// Every attempt needs its own copy of the request body
+	req = req.Clone(req.Context())
+	if req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Body = b
+	}
+
+	// Wait for a free slot, the number of requests in flight is limited
+	release, err := c.acquire(req.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	// Add authentication headers
+	if err := c.authenticate(req); err != nil {
+		return nil, nil, err
+	}
 
 	// Add DomainRobot context header
 	req.Header.Set("X-Domainrobot-Context", c.Context)
 
+	// Add DomainRobot owner headers
+	c.setOwner(req)
+
 	// Send the request
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
 	// Read the server response
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	// Only proceed if it's 200 ok
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
-	}
-
-	// Unmarshel the api response into the proper struct
-	resp := &APIResponse[T]{}
-	err = json.Unmarshal(body, &resp)
-	if err != nil {
-		return nil, err
+		return nil, res, newError(res.StatusCode, body)
 	}
 
-	return resp.Data, err
+	return body, res, nil
 }
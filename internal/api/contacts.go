@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Values of the type of a contact handle.
+const (
+	ContactTypePerson = "PERSON"
+	ContactTypeOrg    = "ORG"
+	ContactTypeRole   = "ROLE"
+)
+
+// Contact describes the contact handle object in the autodns API response.
+type Contact struct {
+	// ID is assigned by the API when the contact is created.
+	ID int64 `json:"id,omitempty"`
+
+	Type         string   `json:"type"`
+	Alias        string   `json:"alias,omitempty"`
+	FirstName    string   `json:"fname,omitempty"`
+	LastName     string   `json:"lname,omitempty"`
+	Organization string   `json:"organization,omitempty"`
+	Title        string   `json:"title,omitempty"`
+	Address      []string `json:"address"`
+	City         string   `json:"city"`
+	PostalCode   string   `json:"pcode"`
+	State        string   `json:"state,omitempty"`
+	Country      string   `json:"country"`
+	Email        string   `json:"email"`
+	Phone        string   `json:"phone,omitempty"`
+	Fax          string   `json:"fax,omitempty"`
+
+	// Extensions are the additional properties some registries require, keyed by the TLD they apply to.
+	Extensions map[string]map[string]string `json:"extensions,omitempty"`
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// GetContact returns the contact handle with the ID.
+func (c *Client) GetContact(ctx context.Context, id int64) (*Contact, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/contact/%d", c.HostURL, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Contact](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("contact does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// IterateContacts returns an iterator over the contact handles matching the filters, the results are fetched page by page.
+func (c *Client) IterateContacts(ctx context.Context, filters []SearchFilter) *SearchIterator[Contact] {
+	return newSearchIterator[Contact](ctx, c, "/contact/_search", filters)
+}
+
+// SearchContacts returns all contact handles matching the filters.
+func (c *Client) SearchContacts(ctx context.Context, filters []SearchFilter) ([]Contact, error) {
+	return c.IterateContacts(ctx, filters).All()
+}
+
+// CreateContact sends an API request to create the contact handle.
+func (c *Client) CreateContact(ctx context.Context, contact *Contact) (*Contact, error) {
+	return c.writeContact(ctx, "POST", fmt.Sprintf("%s/contact", c.HostURL), contact)
+}
+
+// UpdateContact sends an API request to update the contact handle.
+func (c *Client) UpdateContact(ctx context.Context, contact *Contact) (*Contact, error) {
+	return c.writeContact(ctx, "PUT", fmt.Sprintf("%s/contact/%d", c.HostURL, contact.ID), contact)
+}
+
+// DeleteContact sends an API request to delete the contact handle.
+// AutoDNS rejects the request while the contact is used by a domain.
+func (c *Client) DeleteContact(ctx context.Context, id int64) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/contact/%d", c.HostURL, id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = request[any](c, req)
+
+	return err
+}
+
+func (c *Client) writeContact(ctx context.Context, method, url string, contact *Contact) (*Contact, error) {
+	b, err := json.Marshal(contact)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Contact](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of contacts returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DNSKeyFlagsKSK are the flags of key signing keys, their DS records are published at the parent zone.
+const DNSKeyFlagsKSK = 257
+
+// DigestTypeSHA256 is the DS digest type of SHA-256 digests.
+const DigestTypeSHA256 = 2
+
+// DNSKey describes a DNSKEY of a zone signed by AutoDNS.
+type DNSKey struct {
+	Flags     int    `json:"flags"`
+	Protocol  int    `json:"protocol"`
+	Algorithm int    `json:"algorithm"`
+	PublicKey string `json:"publicKey"`
+}
+
+// DS describes the delegation signer record of a key, which links the signed zone to its parent zone.
+type DS struct {
+	KeyTag     int
+	Algorithm  int
+	DigestType int
+	Digest     string
+}
+
+// rdata returns the key in the DNSKEY wire format of RFC 4034.
+func (k DNSKey) rdata() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(k.PublicKey), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	return append([]byte{byte(k.Flags >> 8), byte(k.Flags), byte(k.Protocol), byte(k.Algorithm)}, key...), nil
+}
+
+// KeyTag calculates the key tag of the key, see RFC 4034 appendix B.
+func (k DNSKey) KeyTag() (int, error) {
+	rdata, err := k.rdata()
+	if err != nil {
+		return 0, err
+	}
+
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+
+	return int(ac & 0xFFFF), nil
+}
+
+// DS returns the SHA-256 delegation signer record of the key of the zone with the origin.
+func (k DNSKey) DS(origin string) (DS, error) {
+	rdata, err := k.rdata()
+	if err != nil {
+		return DS{}, err
+	}
+
+	keyTag, err := k.KeyTag()
+	if err != nil {
+		return DS{}, err
+	}
+
+	// The digest covers the owner name in canonical wire format followed by the DNSKEY rdata
+	h := sha256.New()
+	for _, label := range strings.Split(strings.ToLower(strings.TrimSuffix(origin, ".")), ".") {
+		h.Write([]byte{byte(len(label))})
+		h.Write([]byte(label))
+	}
+	h.Write([]byte{0})
+	h.Write(rdata)
+
+	return DS{
+		KeyTag:     keyTag,
+		Algorithm:  k.Algorithm,
+		DigestType: DigestTypeSHA256,
+		Digest:     strings.ToUpper(hex.EncodeToString(h.Sum(nil))),
+	}, nil
+}
+
+// SetZoneDNSSEC enables or disables the DNSSEC signing of the zone.
+// AutoDNS generates the keys when signing is enabled, they're part of the returned zone.
+func (c *Client) SetZoneDNSSEC(ctx context.Context, zoneID ZoneID, enabled bool) (*Zone, error) {
+	return c.ModifyZone(ctx, zoneID, func(zone *Zone) error {
+		zone.DNSSEC = enabled
+		return nil
+	})
+}
+
+// RolloverDNSSECKeys replaces the DNSSEC keys of a signed zone with new ones.
+func (c *Client) RolloverDNSSECKeys(ctx context.Context, zoneID ZoneID) (*Zone, error) {
+	unlock := c.lockZone(zoneID)
+	defer unlock()
+	defer c.invalidateZone(zoneID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.HostURL+zoneID.path()+"/_keyrollover", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Zone](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of zones returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
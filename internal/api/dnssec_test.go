@@ -0,0 +1,30 @@
+package api
+
+import "testing"
+
+// The test key of RFC 4509 section 2.3.
+var testDNSKey = DNSKey{
+	Flags:     256,
+	Protocol:  3,
+	Algorithm: 5,
+	PublicKey: "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/ 2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvx " +
+		"egXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9Xzc nOf+EPbtG9DMBmADjFDc2w/rljwvFw==",
+}
+
+func TestDNSKeyDS(t *testing.T) {
+	ds, err := testDNSKey.DS("dskey.example.com.")
+	if err != nil {
+		t.Fatalf("DS() error = %v", err)
+	}
+
+	want := DS{KeyTag: 60485, Algorithm: 5, DigestType: 2, Digest: "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"}
+	if ds != want {
+		t.Errorf("DS() = %+v, want %+v", ds, want)
+	}
+}
+
+func TestDNSKeyInvalid(t *testing.T) {
+	if _, err := (DNSKey{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "not base64!"}).KeyTag(); err == nil {
+		t.Error("KeyTag() of an invalid key succeeded, want an error")
+	}
+}
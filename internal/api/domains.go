@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Values of the auto renew status of a domain.
+const (
+	AutoRenewStatusTrue  = "TRUE"
+	AutoRenewStatusFalse = "FALSE"
+)
+
+// Values of the registrar status of a domain, a locked domain can't be transferred to another registrar.
+const (
+	RegistrarStatusActive = "ACTIVE"
+	RegistrarStatusLock   = "LOCK"
+)
+
+// ContactRef references a contact handle by its ID.
+type ContactRef struct {
+	ID int64 `json:"id"`
+}
+
+// Domain describes the domain object in the autodns API response.
+type Domain struct {
+	Name        string       `json:"name"`
+	NameServers []NameServer `json:"nameServers"`
+
+	// DNSSECData are the keys whose DS records are published by the registry, the domain is unsigned without keys.
+	DNSSECData []DNSKey `json:"dnssecData"`
+
+	AutoRenewStatus string `json:"autoRenewStatus,omitempty"`
+	RegistrarStatus string `json:"registrarStatus,omitempty"`
+
+	OwnerC *ContactRef `json:"ownerc,omitempty"`
+	AdminC *ContactRef `json:"adminc,omitempty"`
+	TechC  *ContactRef `json:"techc,omitempty"`
+	ZoneC  *ContactRef `json:"zonec,omitempty"`
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// GetDomain returns the registered domain with the name.
+func (c *Client) GetDomain(ctx context.Context, name string) (*Domain, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/domain/%s", c.HostURL, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Domain](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("domain does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// UpdateDomain sends an API request to update the domain.
+// The payload replaces the settings of the domain, so it should start from the current domain.
+func (c *Client) UpdateDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+	d, err := json.Marshal(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/domain/%s", c.HostURL, domain.Name), strings.NewReader(string(d)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Domain](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of domains returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
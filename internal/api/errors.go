@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ResponseStatus describes the status object autodns adds to every API response.
+type ResponseStatus struct {
+	Code string `json:"code"`
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+// MessageObject describes the object a message refers to.
+type MessageObject struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Message describes a message autodns adds to the API response, e.g. a validation error.
+type Message struct {
+	Text     string          `json:"text"`
+	Code     string          `json:"code"`
+	Status   string          `json:"status"`
+	Objects  []MessageObject `json:"objects"`
+	Messages []Message       `json:"messages"`
+}
+
+// Error is returned by the client when the API does not respond with 200 ok.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+	// Body is the raw response body, kept for responses not following the autodns format.
+	Body []byte `json:"-"`
+
+	STID     string         `json:"stid"`
+	Status   ResponseStatus `json:"status"`
+	Messages []Message      `json:"messages"`
+}
+
+// newError creates an Error from the API response, decoding the autodns envelope when possible.
+func newError(statusCode int, body []byte) *Error {
+	e := &Error{}
+
+	// A body in an unknown format is kept in the error as is
+	_ = json.Unmarshal(body, e)
+
+	e.StatusCode = statusCode
+	e.Body = body
+
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Status.Text == "" && len(e.Messages) == 0 {
+		return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "status: %d", e.StatusCode)
+
+	if e.Status.Code != "" {
+		fmt.Fprintf(&b, ", code: %s", e.Status.Code)
+	}
+
+	if e.Status.Text != "" {
+		fmt.Fprintf(&b, ", %s", e.Status.Text)
+	}
+
+	for _, m := range e.MessageTexts() {
+		fmt.Fprintf(&b, "\n- %s", m)
+	}
+
+	return b.String()
+}
+
+// MessageTexts returns a human readable line for every message of the error, including nested ones.
+func (e *Error) MessageTexts() []string {
+	texts := []string{}
+
+	var walk func(messages []Message)
+	walk = func(messages []Message) {
+		for _, m := range messages {
+			text := m.Text
+			if m.Code != "" {
+				text = fmt.Sprintf("%s (%s)", text, m.Code)
+			}
+
+			for _, o := range m.Objects {
+				text = fmt.Sprintf("%s [%s: %s]", text, o.Type, o.Value)
+			}
+
+			texts = append(texts, text)
+			walk(m.Messages)
+		}
+	}
+	walk(e.Messages)
+
+	return texts
+}
+
+// IsNotFound reports whether the requested object does not exist.
+func (e *Error) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsAuthFailure reports whether the credentials or the context were rejected.
+func (e *Error) IsAuthFailure() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsRateLimited reports whether the request was rejected due to too many requests.
+func (e *Error) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsValidation reports whether the API rejected the payload of the request.
+func (e *Error) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsNotFound reports whether err is an API error for an object that does not exist.
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.IsNotFound()
+}
+
+// IsAuthFailure reports whether err is an API error caused by rejected credentials.
+func IsAuthFailure(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.IsAuthFailure()
+}
+
+// IsRateLimited reports whether err is an API error caused by rate limiting.
+func IsRateLimited(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.IsRateLimited()
+}
+
+// IsValidation reports whether err is an API error caused by an invalid payload.
+func IsValidation(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.IsValidation()
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantCode   string
+		wantTexts  []string
+		wantError  string
+	}{
+		{
+			name:       "validation error with nested messages",
+			statusCode: 400,
+			body: `{
+				"stid": "20240101-app1-1",
+				"status": {"code": "EF02020", "text": "The zone data is invalid.", "type": "ERROR"},
+				"messages": [{
+					"text": "The value of an A record must be an IPv4 address.",
+					"code": "EF02020",
+					"status": "ERROR",
+					"objects": [{"type": "record", "value": "www A ::1"}],
+					"messages": [{"text": "Invalid IP address.", "status": "ERROR"}]
+				}]
+			}`,
+			wantCode: "EF02020",
+			wantTexts: []string{
+				"The value of an A record must be an IPv4 address. (EF02020) [record: www A ::1]",
+				"Invalid IP address.",
+			},
+			wantError: "status: 400, code: EF02020, The zone data is invalid.\n- The value of an A record must be an IPv4 address. (EF02020) [record: www A ::1]\n- Invalid IP address.",
+		},
+		{
+			name:       "not found",
+			statusCode: 404,
+			body:       `{"stid": "20240101-app1-2", "status": {"code": "E0205", "text": "Zone could not be found.", "type": "ERROR"}}`,
+			wantCode:   "E0205",
+			wantTexts:  []string{},
+			wantError:  "status: 404, code: E0205, Zone could not be found.",
+		},
+		{
+			name:       "body of a proxy",
+			statusCode: 502,
+			body:       `<html>Bad Gateway</html>`,
+			wantTexts:  []string{},
+			wantError:  "status: 502, body: <html>Bad Gateway</html>",
+		},
+		{
+			name:       "empty body",
+			statusCode: 401,
+			wantTexts:  []string{},
+			wantError:  "status: 401, body: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newError(tt.statusCode, []byte(tt.body))
+
+			if e.StatusCode != tt.statusCode || string(e.Body) != tt.body {
+				t.Errorf("newError() kept status %d and body %q", e.StatusCode, e.Body)
+			}
+
+			if e.Status.Code != tt.wantCode {
+				t.Errorf("newError() code = %q, want %q", e.Status.Code, tt.wantCode)
+			}
+
+			if got := e.MessageTexts(); !slices.Equal(got, tt.wantTexts) {
+				t.Errorf("MessageTexts() = %q, want %q", got, tt.wantTexts)
+			}
+
+			if got := e.Error(); got != tt.wantError {
+				t.Errorf("Error() = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		statusCode                                     int
+		notFound, authFailure, rateLimited, validation bool
+	}{
+		{statusCode: 400, validation: true},
+		{statusCode: 401, authFailure: true},
+		{statusCode: 403, authFailure: true},
+		{statusCode: 404, notFound: true},
+		{statusCode: 422, validation: true},
+		{statusCode: 429, rateLimited: true},
+		{statusCode: 500},
+		{statusCode: 503},
+	}
+
+	for _, tt := range tests {
+		e := newError(tt.statusCode, nil)
+
+		for _, err := range []error{e, fmt.Errorf("wrapped: %w", e)} {
+			if got := IsNotFound(err); got != tt.notFound {
+				t.Errorf("IsNotFound(%v) = %t, want %t", err, got, tt.notFound)
+			}
+
+			if got := IsAuthFailure(err); got != tt.authFailure {
+				t.Errorf("IsAuthFailure(%v) = %t, want %t", err, got, tt.authFailure)
+			}
+
+			if got := IsRateLimited(err); got != tt.rateLimited {
+				t.Errorf("IsRateLimited(%v) = %t, want %t", err, got, tt.rateLimited)
+			}
+
+			if got := IsValidation(err); got != tt.validation {
+				t.Errorf("IsValidation(%v) = %t, want %t", err, got, tt.validation)
+			}
+		}
+	}
+
+	// Errors not returned by the API are never classified
+	for _, err := range []error{nil, errors.New("connection refused")} {
+		if IsNotFound(err) || IsAuthFailure(err) || IsRateLimited(err) || IsValidation(err) {
+			t.Errorf("error %v should not be classified", err)
+		}
+	}
+}
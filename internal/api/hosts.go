@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Host describes the host object in the autodns API response.
+// Hosts are the glue records of nameservers below a domain of the account, they're registered at the registry of the domain.
+type Host struct {
+	Name        string   `json:"name"`
+	IPAddresses []string `json:"ipAddresses"`
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// GetHost returns the host with the name.
+func (c *Client) GetHost(ctx context.Context, name string) (*Host, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.HostURL+"/host/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Host](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("host does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// CreateHost sends an API request to create the host.
+func (c *Client) CreateHost(ctx context.Context, host *Host) (*Host, error) {
+	return c.writeHost(ctx, "POST", c.HostURL+"/host", host)
+}
+
+// UpdateHost sends an API request to update the IP addresses of the host.
+func (c *Client) UpdateHost(ctx context.Context, host *Host) (*Host, error) {
+	return c.writeHost(ctx, "PUT", c.HostURL+"/host/"+url.PathEscape(host.Name), host)
+}
+
+// DeleteHost sends an API request to delete the host.
+// AutoDNS rejects the request while the host is a nameserver of a nameserver group.
+func (c *Client) DeleteHost(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.HostURL+"/host/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = request[any](c, req)
+
+	return err
+}
+
+func (c *Client) writeHost(ctx context.Context, method, url string, host *Host) (*Host, error) {
+	b, err := json.Marshal(host)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Host](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of hosts returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// NameServerGroup describes the nameserver group object in the autodns API response.
+// Zones assigned to a custom group are served by its nameservers, e.g. vanity nameservers below a domain of the account.
+type NameServerGroup struct {
+	Name        string       `json:"name"`
+	NameServers []NameServer `json:"nameServers"`
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// GetNameServerGroup returns the nameserver group with the name.
+func (c *Client) GetNameServerGroup(ctx context.Context, name string) (*NameServerGroup, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.HostURL+"/nameserverGroup/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[NameServerGroup](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("nameserver group does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// CreateNameServerGroup sends an API request to create the nameserver group.
+func (c *Client) CreateNameServerGroup(ctx context.Context, group *NameServerGroup) (*NameServerGroup, error) {
+	return c.writeNameServerGroup(ctx, "POST", c.HostURL+"/nameserverGroup", group)
+}
+
+// UpdateNameServerGroup sends an API request to update the nameservers of the group.
+func (c *Client) UpdateNameServerGroup(ctx context.Context, group *NameServerGroup) (*NameServerGroup, error) {
+	return c.writeNameServerGroup(ctx, "PUT", c.HostURL+"/nameserverGroup/"+url.PathEscape(group.Name), group)
+}
+
+// DeleteNameServerGroup sends an API request to delete the nameserver group.
+// AutoDNS rejects the request while zones are assigned to the group.
+func (c *Client) DeleteNameServerGroup(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.HostURL+"/nameserverGroup/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = request[any](c, req)
+
+	return err
+}
+
+func (c *Client) writeNameServerGroup(ctx context.Context, method, url string, group *NameServerGroup) (*NameServerGroup, error) {
+	b, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[NameServerGroup](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of nameserver groups returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
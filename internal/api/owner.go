@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// Owner selects the subuser the requests are sent on behalf of, so resellers can manage the zones of their customers.
+type Owner struct {
+	User    string
+	Context string
+}
+
+type ownerKey struct{}
+
+// WithOwner returns a context whose requests are sent on behalf of the owner.
+// Empty fields of the owner fall back to the owner of the client.
+func WithOwner(ctx context.Context, owner Owner) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+// owner returns the owner of the requests sent with the context.
+func (c *Client) owner(ctx context.Context) Owner {
+	owner := c.Owner
+
+	if o, ok := ctx.Value(ownerKey{}).(Owner); ok {
+		if o.User != "" {
+			owner.User = o.User
+		}
+
+		if o.Context != "" {
+			owner.Context = o.Context
+		}
+	}
+
+	return owner
+}
+
+// setOwner adds the owner headers of the request context, when there's an owner.
+func (c *Client) setOwner(req *http.Request) {
+	owner := c.owner(req.Context())
+
+	if owner.User != "" {
+		req.Header.Set("X-Domainrobot-Owner-User", owner.User)
+	}
+
+	if owner.Context != "" {
+		req.Header.Set("X-Domainrobot-Owner-Context", owner.Context)
+	}
+}
+
+// zoneKey identifies a zone as seen by an owner, as different owners must not share zone reads or writes.
+type zoneKey struct {
+	Owner
+	ZoneID
+}
+
+func (c *Client) zoneKey(ctx context.Context, zoneID ZoneID) zoneKey {
+	return zoneKey{Owner: c.owner(ctx), ZoneID: zoneID}
+}
@@ -3,8 +3,8 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 )
 
@@ -24,96 +24,109 @@ type ZoneStream struct {
 	Rems []Record `json:"rems"`
 }
 
-// CreateRecords sends an API request to create the records in the JSON payload.
-func (c *Client) CreateRecords(ctx context.Context, zoneID string, records []Record) error {
-	zoneInfo := strings.Split(zoneID, "@")
-	if len(zoneInfo) != 2 {
-		return fmt.Errorf("the zone is must have the format origin@virtualNameServer")
+// DiffRecords returns the stream payload that turns the current records into the desired ones.
+func DiffRecords(current, desired []Record) ZoneStream {
+	zs := ZoneStream{
+		Adds: []Record{},
+		Rems: []Record{},
 	}
 
-	zs, err := json.Marshal(&ZoneStream{
-		Adds: records,
-	})
-	if err != nil {
-		return err
+	for _, r := range desired {
+		if !slices.ContainsFunc(current, r.Equal) {
+			zs.Adds = append(zs.Adds, r)
+		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/zone/%s/_stream", c.HostURL, zoneInfo[0]), strings.NewReader(string(zs)))
-	if err != nil {
-		return err
-	}
-
-	_, err = request[any](c, req)
-	if err != nil {
-		return err
+	for _, r := range current {
+		if !slices.ContainsFunc(desired, r.Equal) {
+			zs.Rems = append(zs.Rems, r)
+		}
 	}
 
-	return nil
+	return zs
 }
 
-// GetRecords Fetches all the records in the zone.
-func (c *Client) GetRecords(ctx context.Context, zoneID string) ([]Record, error) {
-	zoneInfo := strings.Split(zoneID, "@")
-	if len(zoneInfo) != 2 {
-		return nil, fmt.Errorf("the zone is must have the format origin@virtualNameServer")
-	}
+// Equal reports whether both records hold the same data, regardless of their ID.
+func (r Record) Equal(o Record) bool {
+	return r.Name == o.Name && r.Type == o.Type && r.TTL == o.TTL && r.Value == o.Value && r.Pref == o.Pref
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/zone/%s/%s", c.HostURL, zoneInfo[0], zoneInfo[1]), nil)
-	if err != nil {
-		return nil, err
-	}
+// CreateRecords sends an API request to create the records in the JSON payload.
+func (c *Client) CreateRecords(ctx context.Context, zoneID ZoneID, records []Record) error {
+	return c.stream(ctx, zoneID, ZoneStream{
+		Adds: records,
+	})
+}
 
-	res, err := request[Zone](c, req)
+// GetRecords Fetches all the records in the zone.
+func (c *Client) GetRecords(ctx context.Context, zoneID ZoneID) ([]Record, error) {
+	zone, err := c.GetZoneByID(ctx, zoneID)
 	if err != nil {
 		return nil, err
 	}
 
-	return res[0].Records, nil
+	return zone.Records, nil
 }
 
 // UpdateRecords sends an API request to update the records in the JSON payload.
-func (c *Client) UpdateRecords(ctx context.Context, zoneID string, oldRecords, newRecords []Record) error {
-	zoneInfo := strings.Split(zoneID, "@")
-	if len(zoneInfo) != 2 {
-		return fmt.Errorf("the zone is must have the format origin@virtualNameServer")
-	}
-
-	zs, err := json.Marshal(&ZoneStream{
+func (c *Client) UpdateRecords(ctx context.Context, zoneID ZoneID, oldRecords, newRecords []Record) error {
+	return c.stream(ctx, zoneID, ZoneStream{
 		Adds: newRecords,
 		Rems: oldRecords,
 	})
+}
+
+// DeleteRecord sends a delete request to the API.
+func (c *Client) DeleteRecords(ctx context.Context, zoneID ZoneID, records []Record) error {
+	return c.stream(ctx, zoneID, ZoneStream{
+		Rems: records,
+	})
+}
+
+// ModifyRecords computes a stream from the current records of the zone and sends it in a single API request.
+// Like ModifyZone, the zone lock is held from the read to the write and the records aren't read from the cache,
+// so the stream can't be based on stale records. The stream isn't batched with other writes.
+// Nothing is sent when the stream is empty, the error of diff is returned as is.
+func (c *Client) ModifyRecords(ctx context.Context, zoneID ZoneID, diff func(records []Record) (ZoneStream, error)) error {
+	unlock := c.lockZone(zoneID)
+	defer unlock()
+	defer c.invalidateZone(zoneID)
+
+	zone, err := c.fetchZone(ctx, zoneID)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/zone/%s/_stream", c.HostURL, zoneInfo[0]), strings.NewReader(string(zs)))
+	zs, err := diff(zone.Records)
 	if err != nil {
 		return err
 	}
 
-	_, err = request[any](c, req)
-	if err != nil {
-		return err
+	if len(zs.Adds) == 0 && len(zs.Rems) == 0 {
+		return nil
 	}
 
-	return nil
+	return c.postStream(ctx, zoneID, zs)
 }
 
-// DeleteRecord sends a delete request to the API.
-func (c *Client) DeleteRecords(ctx context.Context, zoneID string, records []Record) error {
-	zoneInfo := strings.Split(zoneID, "@")
-	if len(zoneInfo) != 2 {
-		return fmt.Errorf("the zone is must have the format origin@virtualNameServer")
-	}
+// sendStream sends the stream payload to the zone in a single API request.
+func (c *Client) sendStream(ctx context.Context, zoneID ZoneID, stream ZoneStream) error {
+	// Writes to the same zone must not race each other
+	unlock := c.lockZone(zoneID)
+	defer unlock()
+	defer c.invalidateZone(zoneID)
 
-	zs, err := json.Marshal(&ZoneStream{
-		Rems: records,
-	})
+	return c.postStream(ctx, zoneID, stream)
+}
+
+// postStream sends the stream request, the caller must hold the zone lock.
+func (c *Client) postStream(ctx context.Context, zoneID ZoneID, stream ZoneStream) error {
+	zs, err := json.Marshal(&stream)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/zone/%s/_stream", c.HostURL, zoneInfo[0]), strings.NewReader(string(zs)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.HostURL+zoneID.path()+"/_stream", strings.NewReader(string(zs)))
 	if err != nil {
 		return err
 	}
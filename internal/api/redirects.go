@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Values of the type of a redirect.
+const (
+	// RedirectTypeDomain redirects HTTP requests for the source domain to the target URL.
+	RedirectTypeDomain = "DOMAIN"
+	// RedirectTypeEmail forwards the emails sent to the source to the target address.
+	RedirectTypeEmail = "EMAIL"
+)
+
+// Values of the mode of a redirect.
+const (
+	// RedirectModeHTTP redirects temporarily with the status 302.
+	RedirectModeHTTP = "HTTP"
+	// RedirectModeHTTPX redirects permanently with the status 301.
+	RedirectModeHTTPX = "HTTPX"
+	// RedirectModeFrame shows the target in a frame, the source stays in the address bar.
+	RedirectModeFrame = "FRAME"
+	// RedirectModeCatchAll forwards the emails sent to any address of the source domain.
+	RedirectModeCatchAll = "CATCHALL"
+	// RedirectModeSingle forwards the emails sent to the source address.
+	RedirectModeSingle = "SINGLE"
+)
+
+// Redirect describes the redirect object in the autodns API response.
+type Redirect struct {
+	// Source is the domain of domain redirects, and the domain or the address of email redirects.
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Mode   string `json:"mode"`
+
+	// Domain is the domain the source belongs to, AutoDNS derives it from the source when it's not set.
+	Domain string `json:"domain,omitempty"`
+	// Title is the page title of frame redirects.
+	Title string `json:"title,omitempty"`
+
+	// ForwardPath and ForwardQuery append the path and the query of the request to the target of domain redirects.
+	ForwardPath  bool `json:"forwardPath"`
+	ForwardQuery bool `json:"forwardQuery"`
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// GetRedirect returns the redirect of the source.
+func (c *Client) GetRedirect(ctx context.Context, source string) (*Redirect, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.HostURL+"/redirect/"+url.PathEscape(source), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Redirect](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("redirect does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// IterateRedirects returns an iterator over the redirects matching the filters, the results are fetched page by page.
+func (c *Client) IterateRedirects(ctx context.Context, filters []SearchFilter) *SearchIterator[Redirect] {
+	return newSearchIterator[Redirect](ctx, c, "/redirect/_search", filters)
+}
+
+// SearchRedirects returns all redirects matching the filters.
+func (c *Client) SearchRedirects(ctx context.Context, filters []SearchFilter) ([]Redirect, error) {
+	return c.IterateRedirects(ctx, filters).All()
+}
+
+// CreateRedirect sends an API request to create the redirect.
+func (c *Client) CreateRedirect(ctx context.Context, redirect *Redirect) (*Redirect, error) {
+	return c.writeRedirect(ctx, "POST", c.HostURL+"/redirect", redirect)
+}
+
+// UpdateRedirect sends an API request to update the redirect of the source.
+func (c *Client) UpdateRedirect(ctx context.Context, redirect *Redirect) (*Redirect, error) {
+	return c.writeRedirect(ctx, "PUT", c.HostURL+"/redirect/"+url.PathEscape(redirect.Source), redirect)
+}
+
+// DeleteRedirect sends an API request to delete the redirect of the source.
+func (c *Client) DeleteRedirect(ctx context.Context, source string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.HostURL+"/redirect/"+url.PathEscape(source), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = request[any](c, req)
+
+	return err
+}
+
+func (c *Client) writeRedirect(ctx context.Context, method, url string, redirect *Redirect) (*Redirect, error) {
+	b, err := json.Marshal(redirect)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Redirect](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of redirects returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
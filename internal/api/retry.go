@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used when none is configured.
+	DefaultMaxRetries = 3
+	// DefaultRetryWaitMin is the initial backoff used when none is configured.
+	DefaultRetryWaitMin = 1 * time.Second
+	// DefaultRetryWaitMax is the backoff limit used when none is configured.
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// do sends the request and retries it with a jittered exponential backoff on transient failures.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	ctx := req.Context()
+
+	reauthenticated := false
+	for attempt := 0; ; attempt++ {
+		step := totpStep(time.Now())
+		body, res, err := c.send(req)
+
+		// Expired sessions and one-time codes sent right before they rolled over are renewed once
+		if IsAuthFailure(err) && !reauthenticated && c.reauthenticate(res, step) {
+			reauthenticated = true
+			body, res, err = c.send(req)
+		}
+
+		if err == nil || attempt >= c.MaxRetries || !retryable(req, err) {
+			return body, err
+		}
+
+		wait := c.backoff(attempt, res)
+
+		// Give up when the wait would exceed the deadline of the request
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether the failed request can be sent again safely.
+// Idempotent requests are retried on network errors, rate limiting and server errors.
+// Other requests, like zone streams, are only retried when AutoDNS has not processed them.
+func retryable(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// The request never reached AutoDNS when the connection could not be established
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		return idempotent(req)
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusTooManyRequests, apiErr.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case apiErr.StatusCode >= 500:
+		return idempotent(req)
+	}
+
+	return false
+}
+
+// idempotent reports whether sending the request more than once has the same effect as sending it once.
+func idempotent(req *http.Request) bool {
+	if slices.Contains([]string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}, req.Method) {
+		return true
+	}
+
+	return strings.HasSuffix(req.URL.Path, "/_search")
+}
+
+// backoff returns how long to wait before the next attempt, preferring the Retry-After header of the response.
+// The wait never exceeds RetryWaitMax, so a long Retry-After can't stall the caller.
+func (c *Client) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return min(wait, c.RetryWaitMax)
+		}
+	}
+
+	wait := c.RetryWaitMin << attempt
+	if wait <= 0 || wait > c.RetryWaitMax {
+		wait = c.RetryWaitMax
+	}
+
+	// Equal jitter keeps at least half of the backoff while spreading concurrent retries
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+
+	return half + rand.N(half)
+}
+
+// retryAfter parses the Retry-After header, which is either in seconds or an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
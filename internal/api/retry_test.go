@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for the handler, retrying without noticeable waits.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.URL, "4", "user", "password")
+	c.RetryWaitMin = time.Millisecond
+	c.RetryWaitMax = 2 * time.Millisecond
+
+	return c
+}
+
+func TestBackoff(t *testing.T) {
+	c := &Client{RetryWaitMin: time.Second, RetryWaitMax: 30 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 0, min: 500 * time.Millisecond, max: time.Second},
+		{attempt: 3, min: 4 * time.Second, max: 8 * time.Second},
+		{attempt: 10, min: 15 * time.Second, max: 30 * time.Second},
+		// The shift overflows, the backoff must still be clamped
+		{attempt: 64, min: 15 * time.Second, max: 30 * time.Second},
+		{attempt: 100, min: 15 * time.Second, max: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 10 {
+			if got := c.backoff(tt.attempt, nil); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	if got := c.backoff(0, res); got != 3*time.Second {
+		t.Errorf("backoff() with Retry-After = %v, want 3s", got)
+	}
+
+	res.Header.Set("Retry-After", "3600")
+	if got := c.backoff(0, res); got != c.RetryWaitMax {
+		t.Errorf("backoff() with a long Retry-After = %v, want RetryWaitMax", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if got, ok := retryAfter("120"); !ok || got != 2*time.Minute {
+		t.Errorf("retryAfter() in seconds = %v, %t", got, ok)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got, ok := retryAfter(date); !ok || got <= 55*time.Second || got > time.Minute {
+		t.Errorf("retryAfter() as HTTP date = %v, %t, want about a minute", got, ok)
+	}
+
+	past := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	if got, ok := retryAfter(past); !ok || got != 0 {
+		t.Errorf("retryAfter() of a past date = %v, %t, want 0", got, ok)
+	}
+
+	for _, v := range []string{"", "-1", "soon"} {
+		if _, ok := retryAfter(v); ok {
+			t.Errorf("retryAfter(%q) should not be valid", v)
+		}
+	}
+}
+
+func TestRetryIdempotency(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		hangUp   bool
+		attempts int32
+	}{
+		{name: "GET on server error", method: "GET", path: "/zone/example.com/a.ns14.net", status: 500, attempts: 4},
+		{name: "POST on server error", method: "POST", path: "/zone/example.com/_stream", status: 500, attempts: 1},
+		{name: "search on server error", method: "POST", path: "/zone/_search", status: 500, attempts: 4},
+		{name: "POST when unavailable", method: "POST", path: "/zone/example.com/_stream", status: 503, attempts: 4},
+		{name: "POST when rate limited", method: "POST", path: "/zone/example.com/_stream", status: 429, attempts: 4},
+		{name: "POST on validation error", method: "POST", path: "/zone/_search", status: 400, attempts: 1},
+		{name: "GET on network error", method: "GET", path: "/zone/example.com/a.ns14.net", hangUp: true, attempts: 4},
+		{name: "POST on network error", method: "POST", path: "/zone/example.com/_stream", hangUp: true, attempts: 1},
+		{name: "search on network error", method: "POST", path: "/zone/_search", hangUp: true, attempts: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+
+				if tt.hangUp {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+
+				w.WriteHeader(tt.status)
+			})
+
+			req, _ := http.NewRequestWithContext(context.Background(), tt.method, c.HostURL+tt.path, strings.NewReader("{}"))
+			if _, err := c.do(req); err == nil {
+				t.Fatal("do() should fail")
+			}
+
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("do() sent %d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	var attempts atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"filters":[]}` {
+			t.Errorf("attempt %d sent body %q", attempts.Load()+1, body)
+		}
+
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		io.WriteString(w, `{"data":[]}`)
+	})
+
+	req, _ := http.NewRequestWithContext(context.Background(), "POST", c.HostURL+"/zone/_search", strings.NewReader(`{"filters":[]}`))
+	if _, err := c.do(req); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("do() sent %d attempts, want 2", got)
+	}
+}
+
+func TestRetryReauthenticates(t *testing.T) {
+	var logins atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			w.Header().Set(SessionHeader, []string{"", "expired", "valid"}[logins.Add(1)])
+			io.WriteString(w, `{}`)
+			return
+		}
+
+		if r.Header.Get(SessionHeader) != "valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		io.WriteString(w, `{"data":[]}`)
+	})
+	c.AuthMode = AuthModeSession
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", c.HostURL+"/zone/example.com/a.ns14.net", nil)
+	if _, err := c.do(req); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+
+	if got := logins.Load(); got != 2 {
+		t.Errorf("do() logged in %d times, want 2", got)
+	}
+
+	// The session is only renewed once, a failing login isn't repeated on every retry
+	logins.Store(0)
+	c.session.id = "revoked"
+	c.MaxRetries = 0
+
+	if _, err := c.do(req); !IsAuthFailure(err) {
+		t.Errorf("do() with an expired session error = %v, want an auth failure", err)
+	}
+
+	if got := logins.Load(); got != 1 {
+		t.Errorf("do() logged in %d times, want 1", got)
+	}
+}
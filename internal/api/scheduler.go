@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultMaxConcurrentRequests is the number of parallel requests used when none is configured.
+const DefaultMaxConcurrentRequests = 5
+
+// scheduler bounds the number of requests in flight and serializes writes to the same zone.
+type scheduler struct {
+	once  sync.Once
+	slots chan struct{}
+
+	mu    sync.Mutex
+	zones map[ZoneID]*zoneLock
+}
+
+// zoneLock is the lock of a zone, it's dropped once no caller holds or waits for it.
+type zoneLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// acquire blocks until a request slot is free or the context is done.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	c.scheduler.once.Do(func() {
+		c.scheduler.slots = make(chan struct{}, max(c.MaxConcurrentRequests, 1))
+	})
+
+	select {
+	case c.scheduler.slots <- struct{}{}:
+		return func() { <-c.scheduler.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lockZone serializes writes to the zone, so concurrent streams can't race each other.
+// Only the zones being written to have a lock, so the locks don't pile up in long-running clients.
+func (c *Client) lockZone(zoneID ZoneID) func() {
+	s := &c.scheduler
+
+	s.mu.Lock()
+	if s.zones == nil {
+		s.zones = map[ZoneID]*zoneLock{}
+	}
+
+	l, ok := s.zones[zoneID]
+	if !ok {
+		l = &zoneLock{}
+		s.zones[zoneID] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		s.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.zones, zoneID)
+		}
+		s.mu.Unlock()
+	}
+}
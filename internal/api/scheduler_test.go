@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		io.WriteString(w, `{"data":[]}`)
+	})
+	c.MaxConcurrentRequests = 2
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", c.HostURL+"/zone/example.com/a.ns14.net", nil)
+			if _, err := c.do(req); err != nil {
+				t.Errorf("do() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("%d requests were in flight, want 2", got)
+	}
+}
+
+func TestLockZone(t *testing.T) {
+	c := &Client{}
+	zoneA := ZoneID{Origin: "example.com", VirtualNameServer: "a.ns14.net"}
+	zoneB := ZoneID{Origin: "example.org", VirtualNameServer: "a.ns14.net"}
+
+	unlock := c.lockZone(zoneA)
+
+	// Other zones aren't blocked by the lock
+	done := make(chan struct{})
+	go func() {
+		c.lockZone(zoneB)()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lockZone() of another zone is blocked")
+	}
+
+	// The same zone waits until the lock is released
+	locked := make(chan struct{})
+	go func() {
+		c.lockZone(zoneA)()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("lockZone() of the same zone isn't blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	<-locked
+
+	if n := len(c.scheduler.zones); n != 0 {
+		t.Errorf("%d zone locks are left after unlocking, want 0", n)
+	}
+}
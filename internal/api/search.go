@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// DefaultSearchPageSize is the number of results fetched with every search request.
+const DefaultSearchPageSize = 100
+
+// SearchFilter is used to add a filter to a search API request, all AutoDNS searches share the filter format.
+// Filters without a key group their nested filters, e.g. to combine them with OR.
+type SearchFilter struct {
+	Key      string `json:"key,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	// Link combines the filter with the following one, either AND (the default) or OR.
+	Link    string         `json:"link,omitempty"`
+	Filters []SearchFilter `json:"filters,omitempty"`
+}
+
+// View selects the page of the search results.
+type View struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// SearchFilterReq is a set of filters to send with the search API request.
+type SearchFilterReq struct {
+	Filters []SearchFilter `json:"filters"`
+	View    *View          `json:"view,omitempty"`
+}
+
+// SearchIterator pages through the results of an AutoDNS _search endpoint.
+// It's used like a bufio.Scanner: Next fetches the next page when needed,
+// Value returns the current result and Err the error which stopped the iteration.
+type SearchIterator[T any] struct {
+	c       *Client
+	ctx     context.Context
+	url     string
+	filters []SearchFilter
+
+	// PageSize is the number of results fetched with every request.
+	PageSize int
+
+	page   []T
+	value  T
+	offset int
+	total  int
+	done   bool
+	err    error
+}
+
+// newSearchIterator returns an iterator over the results of the search endpoint at the path.
+func newSearchIterator[T any](ctx context.Context, c *Client, path string, filters []SearchFilter) *SearchIterator[T] {
+	return &SearchIterator[T]{
+		c:        c,
+		ctx:      ctx,
+		url:      c.HostURL + path,
+		filters:  filters,
+		PageSize: DefaultSearchPageSize,
+		total:    -1,
+	}
+}
+
+// Next advances to the next result, it returns false when all results have been read or a request failed.
+func (it *SearchIterator[T]) Next() bool {
+	if len(it.page) == 0 && !it.done && it.err == nil {
+		it.fetch()
+	}
+
+	if len(it.page) == 0 {
+		return false
+	}
+
+	it.value, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Value returns the current result.
+func (it *SearchIterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *SearchIterator[T]) Err() error {
+	return it.err
+}
+
+// Total returns the number of results reported by the API, or -1 before the first page has been fetched
+// or when the API doesn't report it.
+func (it *SearchIterator[T]) Total() int {
+	return it.total
+}
+
+// All reads the remaining results.
+func (it *SearchIterator[T]) All() ([]T, error) {
+	values := []T{}
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+
+	return values, it.Err()
+}
+
+// fetch requests the next page of results.
+func (it *SearchIterator[T]) fetch() {
+	body, err := json.Marshal(&SearchFilterReq{
+		Filters: it.filters,
+		View:    &View{Limit: it.PageSize, Offset: it.offset},
+	})
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	req, err := http.NewRequestWithContext(it.ctx, "POST", it.url, strings.NewReader(string(body)))
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	res, err := requestResponse[T](it.c, req)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = res.Data
+	it.offset += len(res.Data)
+
+	if res.Object != nil {
+		it.total = res.Object.Summary
+	}
+
+	// Without the total reported by the API, a page which isn't full is the last one
+	switch {
+	case len(res.Data) == 0:
+		it.done = true
+	case it.total >= 0:
+		it.done = it.offset >= it.total
+	default:
+		it.done = len(res.Data) < it.PageSize
+	}
+}
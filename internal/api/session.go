@@ -0,0 +1,182 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// AuthModeBasic sends the credentials with every request.
+	AuthModeBasic = "basic"
+	// AuthModeSession logs in once and sends the session ID with every request.
+	AuthModeSession = "session"
+
+	// SessionHeader is the header AutoDNS uses for the session ID.
+	SessionHeader = "X-Domainrobot-SessionId"
+)
+
+// session holds the ID of the session the client is logged in with.
+type session struct {
+	mu sync.Mutex
+	id string
+}
+
+// loginReq is the payload of the login request.
+type loginReq struct {
+	Context  int    `json:"context"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// authenticate adds the credentials to the request, logging in first when the client uses sessions.
+func (c *Client) authenticate(req *http.Request) error {
+	if c.AuthMode != AuthModeSession {
+		req.SetBasicAuth(c.Username, c.Password)
+		return c.setTOTP(req)
+	}
+
+	// Concurrent requests wait for the same login
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.id == "" {
+		id, err := c.login(req.Context())
+		if err != nil {
+			return err
+		}
+		c.session.id = id
+	}
+
+	req.Header.Set(SessionHeader, c.session.id)
+
+	return nil
+}
+
+// setTOTP adds the one-time code for accounts with two-factor authentication.
+func (c *Client) setTOTP(req *http.Request) error {
+	if c.TOTPSecret == "" {
+		return nil
+	}
+
+	code, err := TOTPCode(c.TOTPSecret, time.Now())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Domainrobot-2FA-Token", code)
+
+	return nil
+}
+
+// reauthenticate reports whether a request rejected with an auth failure may be sent again with fresh credentials.
+// An expired session is dropped, so the next attempt logs in again, and a one-time code may have rolled over
+// since the attempt started in the given time step.
+func (c *Client) reauthenticate(res *http.Response, step uint64) bool {
+	if c.AuthMode == AuthModeSession {
+		// Failed logins don't have a response, they must not be repeated
+		if res == nil || res.Request == nil || res.Request.Header.Get(SessionHeader) == "" {
+			return false
+		}
+
+		c.expireSession(res.Request.Header.Get(SessionHeader))
+		return true
+	}
+
+	return c.TOTPSecret != "" && totpStep(time.Now()) != step
+}
+
+// expireSession drops the session, unless another request has already logged in again.
+func (c *Client) expireSession(id string) {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.id == id {
+		c.session.id = ""
+	}
+}
+
+// login sends the credentials to the login endpoint and returns the ID of the new session.
+// It's called while a request slot is held, so it doesn't go through the scheduler.
+func (c *Client) login(ctx context.Context) (string, error) {
+	contextID, err := strconv.Atoi(c.Context)
+	if err != nil {
+		return "", fmt.Errorf("the context must be a number to log in, got: %q", c.Context)
+	}
+
+	payload, err := json.Marshal(&loginReq{Context: contextID, User: c.Username, Password: c.Password})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.HostURL+"/login", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.setTOTP(req); err != nil {
+		return "", err
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", newError(res.StatusCode, body)
+	}
+
+	id := res.Header.Get(SessionHeader)
+	if id == "" {
+		return "", fmt.Errorf("the login response does not contain a session id")
+	}
+
+	return id, nil
+}
+
+// Logout ends the session of the client, it does nothing when the client isn't logged in.
+func (c *Client) Logout(ctx context.Context) error {
+	c.session.mu.Lock()
+	id := c.session.id
+	c.session.id = ""
+	c.session.mu.Unlock()
+
+	if id == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.HostURL+"/logout", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(SessionHeader, id)
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return newError(res.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is how long a one-time code is valid, AutoDNS uses the RFC 6238 default.
+	totpPeriod = 30 * time.Second
+	// totpDigits is the number of digits of a one-time code.
+	totpDigits = 6
+)
+
+// TOTPCode returns the one-time code of the base32 encoded secret for the given time, as defined in RFC 6238.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return totpCode(key, totpStep(t)), nil
+}
+
+// decodeTOTPSecret decodes the base32 secret, which authenticator apps show in lower case, grouped and without padding.
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	s = strings.TrimRight(s, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("the TOTP secret must be a base32 encoded string")
+	}
+
+	return key, nil
+}
+
+// totpStep returns the number of the time step the time falls into.
+func totpStep(t time.Time) uint64 {
+	return uint64(t.Unix() / int64(totpPeriod/time.Second))
+}
+
+// totpCode computes the HOTP value of the time step, see RFC 4226.
+func totpCode(key []byte, step uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, step)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range totpDigits {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
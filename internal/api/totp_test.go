@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// The SHA1 test vectors of RFC 6238, truncated to six digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("TOTPCode() error = %v", err)
+		}
+
+		if got != tt.want {
+			t.Errorf("TOTPCode() at %d = %q, want %q", tt.unix, got, tt.want)
+		}
+	}
+
+	// Authenticator apps show the secret in lower case and grouped
+	got, err := TOTPCode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	if err != nil || got != "287082" {
+		t.Errorf("TOTPCode() of a formatted secret = %q, %v", got, err)
+	}
+
+	if _, err := TOTPCode("not base32!", time.Now()); err == nil {
+		t.Errorf("TOTPCode() of an invalid secret should fail")
+	}
+}
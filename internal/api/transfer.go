@@ -0,0 +1,30 @@
+package api
+
+import "context"
+
+const (
+	// ZoneTypePrimary zones are served from the records managed in AutoDNS.
+	ZoneTypePrimary = "PRIMARY"
+	// ZoneTypeSecondary zones are transferred from the primary nameservers.
+	ZoneTypeSecondary = "SECONDARY"
+)
+
+// ZoneTransfer describes the zone transfer settings of a zone.
+type ZoneTransfer struct {
+	// Type is either ZoneTypePrimary (the default) or ZoneTypeSecondary.
+	Type string `json:"type,omitempty"`
+	// Primaries are the IP addresses secondary zones are transferred from.
+	Primaries []string `json:"primaries,omitempty"`
+	// Grants are the IP addresses allowed to transfer the zone with AXFR.
+	Grants []string `json:"grants,omitempty"`
+	// Notifies are the IP addresses receiving a NOTIFY when the zone changes.
+	Notifies []string `json:"notifies,omitempty"`
+}
+
+// SetZoneTransfer replaces the zone transfer settings of the zone.
+func (c *Client) SetZoneTransfer(ctx context.Context, zoneID ZoneID, transfer ZoneTransfer) (*Zone, error) {
+	return c.ModifyZone(ctx, zoneID, func(zone *Zone) error {
+		zone.ZoneTransfer = transfer
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ZoneID identifies a zone by its origin and virtual name server.
+// The same origin can exist on multiple virtual name servers, so both are needed to address a zone.
+type ZoneID struct {
+	Origin            string
+	VirtualNameServer string
+}
+
+// ParseZoneID parses a zone ID in the format origin@virtualNameServer.
+func ParseZoneID(s string) (ZoneID, error) {
+	origin, vns, ok := strings.Cut(s, "@")
+	if !ok || origin == "" || vns == "" || strings.Contains(vns, "@") {
+		return ZoneID{}, fmt.Errorf("the zone id must have the format origin@virtualNameServer, got: %q", s)
+	}
+
+	if strings.ContainsAny(s, "/ ") {
+		return ZoneID{}, fmt.Errorf("the zone id must not contain slashes or spaces, got: %q", s)
+	}
+
+	return ZoneID{Origin: origin, VirtualNameServer: vns}, nil
+}
+
+// String returns the zone ID in the format origin@virtualNameServer.
+func (id ZoneID) String() string {
+	return id.Origin + "@" + id.VirtualNameServer
+}
+
+// path returns the API path of the zone.
+func (id ZoneID) path() string {
+	return "/zone/" + id.Origin + "/" + id.VirtualNameServer
+}
+
+// ID returns the zone ID of the zone.
+func (z *Zone) ID() ZoneID {
+	return ZoneID{Origin: z.Origin, VirtualNameServer: z.VirtualNameServer}
+}
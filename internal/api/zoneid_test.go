@@ -0,0 +1,34 @@
+package api
+
+import "testing"
+
+func TestParseZoneID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ZoneID
+		wantErr bool
+	}{
+		{in: "example.com@a.ns14.net", want: ZoneID{Origin: "example.com", VirtualNameServer: "a.ns14.net"}},
+		{in: "example.com", wantErr: true},
+		{in: "@a.ns14.net", wantErr: true},
+		{in: "example.com@", wantErr: true},
+		{in: "example.com@a.ns14.net@b.ns14.net", wantErr: true},
+		{in: "example.com/foo@a.ns14.net", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseZoneID(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseZoneID(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseZoneID(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+
+		if err == nil && got.String() != tt.in {
+			t.Errorf("ParseZoneID(%q).String() = %q", tt.in, got.String())
+		}
+	}
+}
@@ -8,42 +8,141 @@ import (
 	"strings"
 )
 
-// ZoneFilter is used to add a filter to the zones API request.
-type ZoneFilter struct {
-	Key      string `json:"key"`
-	Value    string `json:"value"`
-	Operator string `json:"operator"`
+// SOA describes the start of authority settings of a zone.
+type SOA struct {
+	Refresh int64  `json:"refresh"`
+	Retry   int64  `json:"retry"`
+	Expire  int64  `json:"expire"`
+	TTL     int64  `json:"ttl"`
+	Email   string `json:"email,omitempty"`
+	// Minimum is the negative caching TTL of the zone, it isn't set when AutoDNS uses its default.
+	Minimum int64 `json:"minimum,omitempty"`
 }
 
-// ZoneFIlterReq is a set of filters to send with the API request.
-type ZoneFilterReq struct {
-	Filters []ZoneFilter `json:"filters"`
+// NameServer describes a nameserver entry of a zone.
+type NameServer struct {
+	Name        string   `json:"name"`
+	TTL         int64    `json:"ttl,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// MainIP describes the IP address the zone apex (and www when enabled) points to.
+type MainIP struct {
+	Address string `json:"address"`
+	TTL     int64  `json:"ttl,omitempty"`
 }
 
 // Zone desribes the zone object in the autodns API response.
 type Zone struct {
-	Origin            string   `json:"origin"`
-	NameServerGroup   string   `json:"nameServerGroup"`
-	VirtualNameServer string   `json:"virtualNameServer"`
-	Records           []Record `json:"resourceRecords"`
+	Origin            string       `json:"origin"`
+	NameServerGroup   string       `json:"nameServerGroup,omitempty"`
+	VirtualNameServer string       `json:"virtualNameServer"`
+	SOA               *SOA         `json:"soa,omitempty"`
+	NameServers       []NameServer `json:"nameServers,omitempty"`
+	Main              *MainIP      `json:"main,omitempty"`
+	WWWInclude        bool         `json:"wwwInclude"`
+	Records           []Record     `json:"resourceRecords"`
+
+	// DNSSEC enables the signing of the zone, the keys are generated by the API.
+	DNSSEC     bool     `json:"dnssec"`
+	DNSSECKeys []DNSKey `json:"dnssecKeys,omitempty"`
+
+	// ZoneTransfer configures secondary zones and the AXFR access to the zone.
+	ZoneTransfer
+
+	// Created and Updated are timestamps set by the API, they're ignored in requests.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
 }
 
 // GetZone returns the zone in autodns matching the origin.
-func (c *Client) GetZone(ctx context.Context, origin string) (*Zone, error) {
-	zf, err := json.Marshal(&ZoneFilterReq{
-		Filters: []ZoneFilter{
-			{
-				Key:      "origin",
-				Value:    origin,
-				Operator: "EQUAL",
-			},
+// The virtual name server selects the zone when the origin exists on more than one, it's ignored when empty.
+func (c *Client) GetZone(ctx context.Context, origin, virtualNameServer string) (*Zone, error) {
+	filters := []SearchFilter{
+		{
+			Key:      "origin",
+			Value:    origin,
+			Operator: "EQUAL",
 		},
+	}
+
+	if virtualNameServer != "" {
+		filters = append(filters, SearchFilter{
+			Key:      "virtualNameServer",
+			Value:    virtualNameServer,
+			Operator: "EQUAL",
+		})
+	}
+
+	it := c.IterateZones(ctx, filters)
+
+	// A second result is enough to know the origin is ambiguous
+	zones := []Zone{}
+	for len(zones) < 2 && it.Next() {
+		zones = append(zones, it.Value())
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(zones) > 1 {
+		return nil, fmt.Errorf("the origin %s exists on more than one virtual name server, the virtual name server must be set", origin)
+	}
+
+	if len(zones) != 1 {
+		return nil, fmt.Errorf("origin does not exist or more than one result has been returned by the API")
+	}
+
+	return &zones[0], nil
+}
+
+// IterateZones returns an iterator over the zones matching the filters, the results are fetched page by page.
+// The zones in search results don't include their records.
+func (c *Client) IterateZones(ctx context.Context, filters []SearchFilter) *SearchIterator[Zone] {
+	return newSearchIterator[Zone](ctx, c, "/zone/_search", filters)
+}
+
+// SearchZones returns all zones matching the filters.
+// The zones in search results don't include their records.
+func (c *Client) SearchZones(ctx context.Context, filters []SearchFilter) ([]Zone, error) {
+	return c.IterateZones(ctx, filters).All()
+}
+
+// GetZoneByID returns the zone identified by the zone ID, including all of its records.
+func (c *Client) GetZoneByID(ctx context.Context, zoneID ZoneID) (*Zone, error) {
+	return c.cachedZone(ctx, zoneID, func() (*Zone, error) {
+		return c.fetchZone(ctx, zoneID)
 	})
+}
+
+// fetchZone requests the zone from the API, bypassing the cache.
+func (c *Client) fetchZone(ctx context.Context, zoneID ZoneID) (*Zone, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.HostURL+zoneID.path(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Zone](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("zone does not exist or more than one result has been returned by the API")
+	}
+
+	return &res[0], nil
+}
+
+// CreateZone sends an API request to create the zone.
+func (c *Client) CreateZone(ctx context.Context, zone *Zone) (*Zone, error) {
+	z, err := json.Marshal(zone)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/zone/_search", c.HostURL), strings.NewReader(string(zf)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/zone", c.HostURL), strings.NewReader(string(z)))
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +153,83 @@ func (c *Client) GetZone(ctx context.Context, origin string) (*Zone, error) {
 	}
 
 	if len(res) != 1 {
-		return nil, fmt.Errorf("origin does not exist or more than one result has been returned by the API")
+		return nil, fmt.Errorf("unexpected number of zones returned by the API: %d", len(res))
+	}
+
+	return &res[0], nil
+}
+
+// UpdateZone sends an API request to update the zone.
+// The payload replaces the zone, so the records must be included to be kept.
+// Use ModifyZone to change a zone read from the API, so concurrent writes to the zone aren't lost.
+func (c *Client) UpdateZone(ctx context.Context, zone *Zone) (*Zone, error) {
+	unlock := c.lockZone(zone.ID())
+	defer unlock()
+	defer c.invalidateZone(zone.ID())
+
+	return c.updateZone(ctx, zone)
+}
+
+// ModifyZone applies modify to the current zone and writes it back.
+// The zone lock is held from the read to the write and the zone isn't read from the cache,
+// so writes to the zone in the meantime can't be overwritten with stale data.
+// The zone is only written when modify succeeds, its error is returned as is.
+func (c *Client) ModifyZone(ctx context.Context, zoneID ZoneID, modify func(zone *Zone) error) (*Zone, error) {
+	unlock := c.lockZone(zoneID)
+	defer unlock()
+	defer c.invalidateZone(zoneID)
+
+	zone, err := c.fetchZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := modify(zone); err != nil {
+		return nil, err
+	}
+
+	return c.updateZone(ctx, zone)
+}
+
+// updateZone sends the API request to replace the zone, the caller must hold the zone lock.
+func (c *Client) updateZone(ctx context.Context, zone *Zone) (*Zone, error) {
+	z, err := json.Marshal(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.HostURL+zone.ID().path(), strings.NewReader(string(z)))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request[Zone](c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("unexpected number of zones returned by the API: %d", len(res))
 	}
 
 	return &res[0], nil
 }
+
+// DeleteZone sends an API request to delete the zone.
+func (c *Client) DeleteZone(ctx context.Context, zoneID ZoneID) error {
+	unlock := c.lockZone(zoneID)
+	defer unlock()
+	defer c.invalidateZone(zoneID)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.HostURL+zoneID.path(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = request[any](c, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
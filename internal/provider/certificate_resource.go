@@ -0,0 +1,745 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &CertificateResource{}
+	_ resource.ResourceWithConfigure      = &CertificateResource{}
+	_ resource.ResourceWithImportState    = &CertificateResource{}
+	_ resource.ResourceWithModifyPlan     = &CertificateResource{}
+	_ resource.ResourceWithValidateConfig = &CertificateResource{}
+)
+
+const (
+	// defaultCertificateProduct is the DV certificate ordered when no product is configured.
+	defaultCertificateProduct = "BASIC_SSL"
+	// defaultIssueTimeout is how long the issuance of a certificate is awaited by default.
+	defaultIssueTimeout = "30m"
+	// defaultRenewalWindow is how long before its expiry a certificate is renewed by default.
+	defaultRenewalWindow = "720h"
+	// validationRecordTTL is the TTL of the records validating the domains of a certificate.
+	validationRecordTTL = 60
+)
+
+func NewCertificateResource() resource.Resource {
+	return &CertificateResource{}
+}
+
+// CertificateResource defines the resource implementation.
+type CertificateResource struct {
+	client *api.Client
+}
+
+// CertificateResourceModel describes the resource data model.
+type CertificateResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	ZoneID                  types.String `tfsdk:"zone_id"`
+	CommonName              types.String `tfsdk:"common_name"`
+	SubjectAlternativeNames types.List   `tfsdk:"subject_alternative_names"`
+	Product                 types.String `tfsdk:"product"`
+	CSRPEM                  types.String `tfsdk:"csr_pem"`
+	PrivateKeyPEM           types.String `tfsdk:"private_key_pem"`
+	IssueTimeout            types.String `tfsdk:"issue_timeout"`
+	RenewalWindow           types.String `tfsdk:"renewal_window"`
+	ValidationRecords       types.List   `tfsdk:"validation_records"`
+	CertificatePEM          types.String `tfsdk:"certificate_pem"`
+	ChainPEM                types.String `tfsdk:"chain_pem"`
+	SerialNumber            types.String `tfsdk:"serial_number"`
+	Expires                 types.String `tfsdk:"expires"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// ValidationRecordModel describes a DNS record validating a domain of the certificate.
+type ValidationRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+var validationRecordAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"value": types.StringType,
+}
+
+func (r *CertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Order a domain validated SSL certificate through AutoDNS. The domains are validated via DNS, " +
+			"the validation records are written to the zone while the certificate is issued and removed afterwards. " +
+			"The certificate is renewed when it expires within the renewal window. Destroying the resource revokes the certificate.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The certificate ID assigned by AutoDNS.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "AutoDNS zone ID of the zone the validation records are written to, all domains of the certificate must be part of it. " +
+					"Must be provided in the format zoneOrigin@zoneVirtualNameServer.",
+				Required: true,
+			},
+			"common_name": schema.StringAttribute{
+				MarkdownDescription: "The domain the certificate is issued for, e.g. www.example.com.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subject_alternative_names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional domains the certificate is valid for.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"product": schema.StringAttribute{
+				MarkdownDescription: "The AutoDNS certificate product. Defaults to `" + defaultCertificateProduct + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultCertificateProduct),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"csr_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate signing request, its common name must match `common_name`. " +
+					"When it's not set, an ECDSA P-256 key and a CSR for the domains are generated.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key generated for the certificate, null when `csr_pem` is set.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"issue_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the certificate to be issued, e.g. `1h`. Defaults to `" + defaultIssueTimeout + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultIssueTimeout),
+			},
+			"renewal_window": schema.StringAttribute{
+				MarkdownDescription: "How long before its expiry the certificate is renewed, e.g. `720h` for 30 days. Defaults to `" + defaultRenewalWindow + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultRenewalWindow),
+			},
+			"validation_records": schema.ListNestedAttribute{
+				MarkdownDescription: "The DNS records validating the domains of the certificate.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Fully qualified name of the record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the record, `TXT` or `CNAME`.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Value of the record.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"certificate_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chain_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded intermediate certificates of the certificate authority.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial_number": schema.StringAttribute{
+				MarkdownDescription: "Serial number of the certificate as a hex string.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires": schema.StringAttribute{
+				MarkdownDescription: "When the certificate expires, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *CertificateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state CertificateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	window, err := time.ParseDuration(plan.RenewalWindow.ValueString())
+	if err != nil {
+		return
+	}
+
+	// Certificates which haven't been issued yet have no expiry
+	expires, err := time.Parse(time.RFC3339, state.Expires.ValueString())
+	if err != nil || time.Until(expires) > window {
+		return
+	}
+
+	tflog.Debug(ctx, "renewing the certificate within its renewal window", map[string]any{"expires": state.Expires.ValueString()})
+
+	// The renewed certificate is issued with new values
+	plan.ValidationRecords = types.ListUnknown(types.ObjectType{AttrTypes: validationRecordAttrTypes})
+	plan.CertificatePEM = types.StringUnknown()
+	plan.ChainPEM = types.StringUnknown()
+	plan.SerialNumber = types.StringUnknown()
+	plan.Expires = types.StringUnknown()
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *CertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CertificateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	// Generate a key when no CSR has been configured
+	plan.PrivateKeyPEM = types.StringNull()
+	if plan.CSRPEM.IsUnknown() {
+		domains := []string{plan.CommonName.ValueString()}
+		sans := []string{}
+		resp.Diagnostics.Append(plan.SubjectAlternativeNames.ElementsAs(ctx, &sans, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		keyPEM, csrPEM, err := generateCSR(append(domains, sans...))
+		if err != nil {
+			resp.Diagnostics.AddError("Key Generation Failed", fmt.Sprintf("Unable to generate the key of the certificate: %s", err))
+			return
+		}
+
+		plan.PrivateKeyPEM = types.StringValue(keyPEM)
+		plan.CSRPEM = types.StringValue(csrPEM)
+	}
+
+	certificate, diags := r.order(ctx, &plan, nil)
+	resp.Diagnostics.Append(diags...)
+
+	// A certificate which has been ordered is saved even when it hasn't been issued,
+	// terraform marks it as tainted so it's ordered again with the next apply
+	if certificate == nil {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenCertificate(ctx, certificate, &plan)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CertificateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	id := parseCertificateID(&resp.Diagnostics, state.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certificate, err := r.client.GetCertificate(ctx, id)
+	if api.IsNotFound(err) {
+		// The certificate has been revoked outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the certificate", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenCertificate(ctx, certificate, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CertificateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	id := parseCertificateID(&resp.Diagnostics, plan.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var certificate *api.Certificate
+	var err error
+
+	// The certificate is unknown when it's renewed, other changes only affect terraform
+	if plan.CertificatePEM.IsUnknown() {
+		var diags diag.Diagnostics
+		certificate, diags = r.order(ctx, &plan, &id)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		certificate, err = r.client.GetCertificate(ctx, id)
+		if err != nil {
+			appendClientError(&resp.Diagnostics, "Could not fetch the certificate", err, path.Root("id"), path.Empty())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(flattenCertificate(ctx, certificate, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CertificateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	id := parseCertificateID(&resp.Diagnostics, state.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API call to revoke the certificate, there's nothing left to do when it's gone
+	err := r.client.DeleteCertificate(ctx, id)
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to revoke the certificate", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *CertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if id, err := strconv.ParseInt(req.ID, 10, 64); err != nil || id <= 0 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: CERTIFICATE_ID. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+func (r *CertificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config CertificateResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, value := range map[string]types.String{"issue_timeout": config.IssueTimeout, "renewal_window": config.RenewalWindow} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if d, err := time.ParseDuration(value.ValueString()); err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Wrong Attribute Configuration",
+				fmt.Sprintf("Value is not a positive duration like 30m or 720h: %s", value.ValueString()),
+			)
+		}
+	}
+
+	// Check the CSR when it's already known to terraform
+	if config.CSRPEM.IsNull() || config.CSRPEM.IsUnknown() {
+		return
+	}
+
+	csr, err := parseCSR(config.CSRPEM.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("csr_pem"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("Value is not a valid PEM encoded certificate signing request: %s", err),
+		)
+		return
+	}
+
+	if !config.CommonName.IsUnknown() && csr.Subject.CommonName != config.CommonName.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("csr_pem"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("The common name of the CSR %q doesn't match the common_name %q.", csr.Subject.CommonName, config.CommonName.ValueString()),
+		)
+	}
+}
+
+// order orders the certificate, or renews the certificate with the ID, and waits until it's issued.
+// The domains are validated with records written to the zone of the model, which are removed again afterwards.
+// The certificate is returned once it has been ordered, even when the issuance failed.
+func (r *CertificateResource) order(ctx context.Context, model *CertificateResourceModel, renew *int64) (*api.Certificate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	zoneID := parseZoneID(&diags, path.Root("zone_id"), model.ZoneID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	timeout, err := time.ParseDuration(model.IssueTimeout.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("issue_timeout"), "Wrong Attribute Configuration", err.Error())
+		return nil, diags
+	}
+
+	sans := []string{}
+	diags.Append(model.SubjectAlternativeNames.ElementsAs(ctx, &sans, true)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	certificate := &api.Certificate{
+		Name:                    model.CommonName.ValueString(),
+		Product:                 model.Product.ValueString(),
+		CSR:                     model.CSRPEM.ValueString(),
+		SubjectAlternativeNames: sans,
+	}
+
+	prepared, err := r.client.PrepareCertificateOrder(ctx, certificate)
+	if err != nil {
+		appendClientError(&diags, "Unable to prepare the certificate order", err, path.Empty(), path.Root("csr_pem"))
+		return nil, diags
+	}
+
+	records, err := validationRecords(zoneID, prepared.Authentication)
+	if err != nil {
+		diags.AddAttributeError(path.Root("zone_id"), "Wrong Attribute Configuration", err.Error())
+		return nil, diags
+	}
+
+	// API call to write the validation records, so the certificate can be issued right away.
+	// Records already in the zone, e.g. left over from an aborted order, can't be added again and are kept.
+	var added []api.Record
+	err = r.client.ModifyRecords(ctx, zoneID, func(current []api.Record) (api.ZoneStream, error) {
+		added = missingRecords(current, records)
+		return api.ZoneStream{Adds: added}, nil
+	})
+	if err != nil {
+		appendClientError(&diags, "Unable to create the validation records", err, path.Root("zone_id"), path.Empty())
+		return nil, diags
+	}
+
+	defer func() {
+		if len(added) == 0 {
+			return
+		}
+
+		// The records added by this order are removed even when the issuance failed or timed out
+		err := r.client.DeleteRecords(context.WithoutCancel(ctx), zoneID, added)
+		if err != nil {
+			diags.AddWarning("Validation Records Not Removed", fmt.Sprintf("Unable to remove the validation records from the zone %s, got error: %s", zoneID, err))
+		}
+	}()
+
+	if renew != nil {
+		certificate.ID = *renew
+		certificate, err = r.client.RenewCertificate(ctx, certificate)
+	} else {
+		certificate, err = r.client.CreateCertificate(ctx, certificate)
+	}
+
+	if err != nil {
+		appendClientError(&diags, "Unable to order the certificate", err, path.Empty(), path.Root("csr_pem"))
+		return nil, diags
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	issued, err := r.client.WaitForCertificate(waitCtx, certificate.ID)
+	if err != nil {
+		diags.AddError(
+			"Certificate Not Issued",
+			fmt.Sprintf("The certificate %d has been ordered, but it has not been issued: %s", certificate.ID, err),
+		)
+		return certificate, diags
+	}
+
+	return issued, diags
+}
+
+// missingRecords returns the records which aren't in the zone yet, regardless of their TTL.
+func missingRecords(current, records []api.Record) []api.Record {
+	return slices.DeleteFunc(slices.Clone(records), func(r api.Record) bool {
+		return slices.ContainsFunc(current, func(c api.Record) bool {
+			return c.Name == r.Name && c.Type == r.Type && c.Value == r.Value
+		})
+	})
+}
+
+// validationRecords returns the records of the zone for the domain validations.
+func validationRecords(zoneID api.ZoneID, validations []api.DomainValidation) ([]api.Record, error) {
+	records := []api.Record{}
+
+	for _, v := range validations {
+		name := strings.TrimSuffix(v.Name, ".")
+		if name != zoneID.Origin {
+			var ok bool
+			name, ok = strings.CutSuffix(name, "."+zoneID.Origin)
+			if !ok {
+				return nil, fmt.Errorf("the validation record %s of the domain %s is not part of the zone %s", v.Name, v.Domain, zoneID)
+			}
+		} else {
+			name = ""
+		}
+
+		records = append(records, api.Record{Name: name, Type: v.Type, TTL: validationRecordTTL, Value: v.Value})
+	}
+
+	return records, nil
+}
+
+// flattenCertificate maps the certificate returned by the API into the model.
+func flattenCertificate(ctx context.Context, certificate *api.Certificate, model *CertificateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(strconv.FormatInt(certificate.ID, 10))
+	model.CommonName = types.StringValue(certificate.Name)
+	model.Product = types.StringValue(certificate.Product)
+	// AutoDNS may re-encode the CSR, the planned one is kept as long as it's the same request
+	if !sameCSR(model.CSRPEM.ValueString(), certificate.CSR) {
+		model.CSRPEM = types.StringValue(certificate.CSR)
+	}
+	model.CertificatePEM = optionalString(certificate.Server)
+	model.ChainPEM = optionalString(strings.Join(certificate.Chain, ""))
+	model.SerialNumber = optionalString(certificate.SerialNumber)
+
+	model.Expires = types.StringNull()
+	if certificate.Expire != "" {
+		expires, err := time.Parse("2006-01-02T15:04:05.000-0700", certificate.Expire)
+		if err != nil {
+			diags.AddError("Invalid Certificate", fmt.Sprintf("AutoDNS returned an invalid expiry for the certificate %d: %s", certificate.ID, err))
+			return diags
+		}
+
+		model.Expires = types.StringValue(expires.UTC().Format(time.RFC3339))
+	}
+
+	if len(certificate.SubjectAlternativeNames) != 0 || !model.SubjectAlternativeNames.IsNull() {
+		sans := certificate.SubjectAlternativeNames
+		if sans == nil {
+			sans = []string{}
+		}
+
+		tfSANs, d := types.ListValueFrom(ctx, types.StringType, sans)
+		diags.Append(d...)
+		model.SubjectAlternativeNames = tfSANs
+	}
+
+	records := []ValidationRecordModel{}
+	for _, v := range certificate.Authentication {
+		records = append(records, ValidationRecordModel{
+			Name:  types.StringValue(v.Name),
+			Type:  types.StringValue(v.Type),
+			Value: types.StringValue(v.Value),
+		})
+	}
+
+	tfRecords, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: validationRecordAttrTypes}, records)
+	diags.Append(d...)
+	model.ValidationRecords = tfRecords
+
+	// Imported certificates don't know the key, nor the settings of the resource
+	if model.PrivateKeyPEM.IsUnknown() {
+		model.PrivateKeyPEM = types.StringNull()
+	}
+
+	return diags
+}
+
+// parseCertificateID parses the certificate ID of the model, adding an error when it's not a number.
+func parseCertificateID(diags *diag.Diagnostics, value types.String) int64 {
+	id, err := strconv.ParseInt(value.ValueString(), 10, 64)
+	if err != nil {
+		diags.AddAttributeError(path.Root("id"), "Invalid Certificate ID", fmt.Sprintf("Value is not a certificate ID: %s", value.ValueString()))
+	}
+
+	return id
+}
+
+// generateCSR generates an ECDSA P-256 key and a CSR for the domains, the first domain is the common name.
+func generateCSR(domains []string) (string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	names := []string{}
+	for _, d := range domains {
+		if !slices.Contains(names, d) {
+			names = append(names, d)
+		}
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: domains[0]},
+		DNSNames: names,
+	}, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	return string(keyPEM), string(csrPEM), nil
+}
+
+// parseCSR parses the PEM encoded certificate signing request.
+func parseCSR(csrPEM string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("no CERTIFICATE REQUEST block found")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return csr, csr.CheckSignature()
+}
+
+// sameCSR reports whether both PEM encoded certificate signing requests contain the same DER encoded request.
+func sameCSR(a, b string) bool {
+	blockA, _ := pem.Decode([]byte(a))
+	blockB, _ := pem.Decode([]byte(b))
+
+	return blockA != nil && blockB != nil && bytes.Equal(blockA.Bytes, blockB.Bytes)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// certificateProduct is ordered by the tests, they're skipped in live mode unless it's set as certificates are billed.
+var certificateProduct = testAccEnv("TF_AUTODNS_CERTIFICATE_PRODUCT", "BASIC_SSL")
+
+func testAccCertificateResourceConfig(renewalWindow string) string {
+	return fmt.Sprintf(`
+resource "autodns_certificate" "test" {
+  zone_id                   = %q
+  common_name               = "www.%s"
+  subject_alternative_names = [%q]
+  product                   = %q
+  issue_timeout             = "5m"
+  renewal_window            = %q
+}
+`, zoneID, zoneOrigin, zoneOrigin, certificateProduct, renewalWindow)
+}
+
+func TestAccCertificateResource(t *testing.T) {
+	if certificateProduct == "" {
+		t.Skip("TF_AUTODNS_CERTIFICATE_PRODUCT must be set to a DV certificate product for certificate_resource tests to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCertificateResourceConfig("24h"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("id"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("csr_pem"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("private_key_pem"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("certificate_pem"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("expires"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("validation_records"), knownvalue.ListSizeExact(2)),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "autodns_certificate.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"zone_id", "private_key_pem", "issue_timeout", "renewal_window"},
+			},
+			// Renewal testing, the certificate expires within the window and is renewed with every apply
+			{
+				Config: testAccCertificateResourceConfig("87600h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("autodns_certificate.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("autodns_certificate.test", tfjsonpath.New("certificate_pem")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_certificate.test", tfjsonpath.New("certificate_pem"), knownvalue.NotNull()),
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSameCSR(t *testing.T) {
+	_, csr, err := generateCSR([]string{"www.example.com"})
+	if err != nil {
+		t.Fatalf("generateCSR() error = %v", err)
+	}
+
+	_, other, err := generateCSR([]string{"www.example.com"})
+	if err != nil {
+		t.Fatalf("generateCSR() error = %v", err)
+	}
+
+	// The same request with Windows line endings and without the trailing newline
+	reencoded := strings.TrimSpace(strings.ReplaceAll(csr, "\n", "\r\n"))
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: csr, b: csr, want: true},
+		{a: csr, b: reencoded, want: true},
+		{a: csr, b: other, want: false},
+		{a: "", b: csr, want: false},
+		{a: csr, b: "not a CSR", want: false},
+	}
+
+	for i, tt := range tests {
+		if got := sameCSR(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameCSR() of case %d = %t, want %t", i, got, tt.want)
+		}
+	}
+}
+
+func TestMissingRecords(t *testing.T) {
+	leftover := api.Record{Name: "_dnsauth.www", Type: "TXT", TTL: 300, Value: "token-1"}
+	records := []api.Record{
+		{Name: "_dnsauth.www", Type: "TXT", TTL: validationRecordTTL, Value: "token-1"},
+		{Name: "_dnsauth", Type: "TXT", TTL: validationRecordTTL, Value: "token-2"},
+	}
+
+	got := missingRecords([]api.Record{leftover, {Name: "_dnsauth", Type: "TXT", TTL: 300, Value: "old-token"}}, records)
+	if !slices.Equal(got, records[1:]) {
+		t.Errorf("missingRecords() = %+v, want only the record which isn't in the zone yet", got)
+	}
+
+	if got := missingRecords(nil, records); !slices.Equal(got, records) {
+		t.Errorf("missingRecords() of an empty zone = %+v, want all records", got)
+	}
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &ContactDataSource{}
+	_ datasource.DataSourceWithConfigure      = &ContactDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &ContactDataSource{}
+)
+
+func NewContactDataSource() datasource.DataSource {
+	return &ContactDataSource{}
+}
+
+// ContactDataSource defines the data source implementation.
+type ContactDataSource struct {
+	client *api.Client
+}
+
+func (d *ContactDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contact"
+}
+
+func (d *ContactDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up an AutoDNS contact handle by its ID or its alias, e.g. to assign a contact maintained outside of terraform to an `autodns_domain`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The contact ID. Either `id` or `alias` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "Alias of the contact, it must match exactly one contact. Either `id` or `alias` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the contact, one of `PERSON`, `ORG` or `ROLE`.",
+				Computed:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "First name of the contact.",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "Last name of the contact.",
+				Computed:            true,
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization of the contact.",
+				Computed:            true,
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the contact.",
+				Computed:            true,
+			},
+			"address": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Street address lines of the contact.",
+				Computed:            true,
+			},
+			"city": schema.StringAttribute{
+				MarkdownDescription: "City of the contact.",
+				Computed:            true,
+			},
+			"postal_code": schema.StringAttribute{
+				MarkdownDescription: "Postal code of the contact.",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State or province of the contact.",
+				Computed:            true,
+			},
+			"country": schema.StringAttribute{
+				MarkdownDescription: "Country of the contact as an ISO 3166-1 alpha-2 code.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the contact.",
+				Computed:            true,
+			},
+			"phone": schema.StringAttribute{
+				MarkdownDescription: "Phone number of the contact.",
+				Computed:            true,
+			},
+			"fax": schema.StringAttribute{
+				MarkdownDescription: "Fax number of the contact.",
+				Computed:            true,
+			},
+			"extensions": schema.MapAttribute{
+				ElementType:         types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "Additional properties required by some registries, keyed by the TLD they apply to.",
+				Computed:            true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerDataSourceAttributes())
+}
+
+func (d *ContactDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ContactDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config ContactResourceModel
+
+	// Read the data source config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ID.IsNull() == config.Alias.IsNull() && !config.ID.IsUnknown() && !config.Alias.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Wrong Attribute Configuration",
+			"Exactly one of id and alias must be set to look up the contact.",
+		)
+		return
+	}
+
+	parseContactID(&resp.Diagnostics, path.Root("id"), config.ID)
+}
+
+func (d *ContactDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ContactResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, config.OwnerUser, config.OwnerContext)
+
+	var contact *api.Contact
+
+	// API Call
+	if !config.ID.IsNull() {
+		ref := parseContactID(&resp.Diagnostics, path.Root("id"), config.ID)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		contact, err = d.client.GetContact(ctx, ref.ID)
+		if err != nil {
+			appendClientError(&resp.Diagnostics, "Unable to read the contact", err, path.Root("id"), path.Empty())
+			return
+		}
+	} else {
+		contacts, err := d.client.SearchContacts(ctx, []api.SearchFilter{
+			{Key: "alias", Value: config.Alias.ValueString(), Operator: "EQUAL"},
+		})
+		if err != nil {
+			appendClientError(&resp.Diagnostics, "Unable to search the contacts", err, path.Empty(), path.Empty())
+			return
+		}
+
+		if len(contacts) != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("alias"),
+				"Contact Not Found",
+				fmt.Sprintf("Expected exactly one contact with the alias %q, found %d.", config.Alias.ValueString(), len(contacts)),
+			)
+			return
+		}
+
+		contact = &contacts[0]
+	}
+
+	// Map response body to model
+	resp.Diagnostics.Append(flattenContact(ctx, contact, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,436 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ContactResource{}
+	_ resource.ResourceWithConfigure      = &ContactResource{}
+	_ resource.ResourceWithImportState    = &ContactResource{}
+	_ resource.ResourceWithValidateConfig = &ContactResource{}
+)
+
+// contactTypes are the types of contact handles supported by AutoDNS.
+var contactTypes = []string{api.ContactTypePerson, api.ContactTypeOrg, api.ContactTypeRole}
+
+func NewContactResource() resource.Resource {
+	return &ContactResource{}
+}
+
+// ContactResource defines the resource implementation.
+type ContactResource struct {
+	client *api.Client
+}
+
+// ContactResourceModel describes the resource data model, it's shared with the data source.
+type ContactResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Type         types.String `tfsdk:"type"`
+	Alias        types.String `tfsdk:"alias"`
+	FirstName    types.String `tfsdk:"first_name"`
+	LastName     types.String `tfsdk:"last_name"`
+	Organization types.String `tfsdk:"organization"`
+	Title        types.String `tfsdk:"title"`
+	Address      types.List   `tfsdk:"address"`
+	City         types.String `tfsdk:"city"`
+	PostalCode   types.String `tfsdk:"postal_code"`
+	State        types.String `tfsdk:"state"`
+	Country      types.String `tfsdk:"country"`
+	Email        types.String `tfsdk:"email"`
+	Phone        types.String `tfsdk:"phone"`
+	Fax          types.String `tfsdk:"fax"`
+	Extensions   types.Map    `tfsdk:"extensions"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (r *ContactResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contact"
+}
+
+func (r *ContactResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage an AutoDNS contact handle, e.g. to assign it to an `autodns_domain`. " +
+			"AutoDNS refuses to delete contacts which are still assigned to a domain.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The contact ID assigned by AutoDNS.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the contact, one of `PERSON`, `ORG` or `ROLE`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "Alias to find the contact by, e.g. in the web interface or with the `autodns_contact` data source.",
+				Optional:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "First name of the contact, required for `PERSON` and `ROLE` contacts.",
+				Optional:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "Last name of the contact, required for `PERSON` and `ROLE` contacts.",
+				Optional:            true,
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "Organization of the contact, required for `ORG` contacts.",
+				Optional:            true,
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the contact, e.g. Dr.",
+				Optional:            true,
+			},
+			"address": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Street address lines of the contact.",
+				Required:            true,
+			},
+			"city": schema.StringAttribute{
+				MarkdownDescription: "City of the contact.",
+				Required:            true,
+			},
+			"postal_code": schema.StringAttribute{
+				MarkdownDescription: "Postal code of the contact.",
+				Required:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State or province of the contact.",
+				Optional:            true,
+			},
+			"country": schema.StringAttribute{
+				MarkdownDescription: "Country of the contact as an upper case ISO 3166-1 alpha-2 code, e.g. DE.",
+				Required:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the contact.",
+				Required:            true,
+			},
+			"phone": schema.StringAttribute{
+				MarkdownDescription: "Phone number of the contact in the format +49-30-1234567.",
+				Optional:            true,
+			},
+			"fax": schema.StringAttribute{
+				MarkdownDescription: "Fax number of the contact in the format +49-30-1234567.",
+				Optional:            true,
+			},
+			"extensions": schema.MapAttribute{
+				ElementType:         types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "Additional properties required by some registries, keyed by the TLD they apply to, e.g. `{ it = { entity_type = \"1\" } }`.",
+				Optional:            true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *ContactResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ContactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	contact := &api.Contact{}
+	resp.Diagnostics.Append(expandContact(ctx, plan, contact)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	contact, err := r.client.CreateContact(ctx, contact)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to create the contact", err, path.Empty(), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenContact(ctx, contact, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ContactResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ContactResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	ref := parseContactID(&resp.Diagnostics, path.Root("id"), state.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contact, err := r.client.GetContact(ctx, ref.ID)
+	if api.IsNotFound(err) {
+		// The contact has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the contact", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenContact(ctx, contact, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ContactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	ref := parseContactID(&resp.Diagnostics, path.Root("id"), plan.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contact := &api.Contact{ID: ref.ID}
+	resp.Diagnostics.Append(expandContact(ctx, plan, contact)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	contact, err := r.client.UpdateContact(ctx, contact)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the contact", err, path.Root("id"), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenContact(ctx, contact, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ContactResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ContactResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	ref := parseContactID(&resp.Diagnostics, path.Root("id"), state.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API call to delete the contact, there's nothing left to do when it's gone
+	err := r.client.DeleteContact(ctx, ref.ID)
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to delete the contact", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *ContactResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if id, err := strconv.ParseInt(req.ID, 10, 64); err != nil || id <= 0 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: CONTACT_ID. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+func (r *ContactResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ContactResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip when the type is still unknown to terraform
+	if config.Type.IsUnknown() {
+		return
+	}
+
+	contactType := config.Type.ValueString()
+	if !slices.Contains(contactTypes, contactType) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("Unsupported contact type %q, must be one of %s.", contactType, strings.Join(contactTypes, ", ")),
+		)
+		return
+	}
+
+	required := []string{"first_name", "last_name"}
+	values := []types.String{config.FirstName, config.LastName}
+	if contactType == api.ContactTypeOrg {
+		required = []string{"organization"}
+		values = []types.String{config.Organization}
+	}
+
+	for i, value := range values {
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(required[i]),
+				"Missing Attribute Configuration",
+				fmt.Sprintf("The attribute %s is required for contacts of the type %s.", required[i], contactType),
+			)
+		}
+	}
+}
+
+// expandContact applies the settings from the model on top of the given contact.
+func expandContact(ctx context.Context, model ContactResourceModel, contact *api.Contact) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	contact.Type = model.Type.ValueString()
+	contact.Alias = model.Alias.ValueString()
+	contact.FirstName = model.FirstName.ValueString()
+	contact.LastName = model.LastName.ValueString()
+	contact.Organization = model.Organization.ValueString()
+	contact.Title = model.Title.ValueString()
+	contact.City = model.City.ValueString()
+	contact.PostalCode = model.PostalCode.ValueString()
+	contact.State = model.State.ValueString()
+	contact.Country = model.Country.ValueString()
+	contact.Email = model.Email.ValueString()
+	contact.Phone = model.Phone.ValueString()
+	contact.Fax = model.Fax.ValueString()
+
+	contact.Address = []string{}
+	diags.Append(model.Address.ElementsAs(ctx, &contact.Address, false)...)
+
+	contact.Extensions = nil
+	if !model.Extensions.IsNull() {
+		diags.Append(model.Extensions.ElementsAs(ctx, &contact.Extensions, false)...)
+	}
+
+	return diags
+}
+
+// flattenContact maps the contact returned by the API into the model.
+func flattenContact(ctx context.Context, contact *api.Contact, model *ContactResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(strconv.FormatInt(contact.ID, 10))
+	model.Type = types.StringValue(contact.Type)
+	model.Alias = optionalString(contact.Alias)
+	model.FirstName = optionalString(contact.FirstName)
+	model.LastName = optionalString(contact.LastName)
+	model.Organization = optionalString(contact.Organization)
+	model.Title = optionalString(contact.Title)
+	model.City = types.StringValue(contact.City)
+	model.PostalCode = types.StringValue(contact.PostalCode)
+	model.State = optionalString(contact.State)
+	model.Country = types.StringValue(contact.Country)
+	model.Email = types.StringValue(contact.Email)
+	model.Phone = optionalString(contact.Phone)
+	model.Fax = optionalString(contact.Fax)
+
+	address := contact.Address
+	if address == nil {
+		address = []string{}
+	}
+
+	tfAddress, d := types.ListValueFrom(ctx, types.StringType, address)
+	diags.Append(d...)
+	model.Address = tfAddress
+
+	// Keep an empty map of the configuration, AutoDNS doesn't return empty extensions
+	if len(contact.Extensions) == 0 && (model.Extensions.IsNull() || len(model.Extensions.Elements()) == 0) {
+		return diags
+	}
+
+	tfExtensions, d := types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, contact.Extensions)
+	diags.Append(d...)
+	model.Extensions = tfExtensions
+
+	return diags
+}
+
+// optionalString returns the value of an optional attribute, null when AutoDNS returned an empty string.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testContactAlias = "acctest-" + acctest.RandString(8)
+
+func testAccContactResourceConfig(email string) string {
+	return fmt.Sprintf(`
+resource "autodns_contact" "test" {
+  type       = "PERSON"
+  alias      = %q
+  first_name = "Jane"
+  last_name  = "Doe"
+
+  address     = ["Teststraße 1"]
+  city        = "Berlin"
+  postal_code = "10115"
+  country     = "DE"
+  email       = %q
+  phone       = "+49-30-1234567"
+
+  extensions = {
+    it = {
+      entity_type = "1"
+    }
+  }
+}
+
+data "autodns_contact" "test" {
+  alias = autodns_contact.test.alias
+}
+`, testContactAlias, email)
+}
+
+func TestAccContactResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccContactResourceConfig("jane@acctest.dev"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_contact.test", tfjsonpath.New("id"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("autodns_contact.test", tfjsonpath.New("organization"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("autodns_contact.test", tfjsonpath.New("extensions").AtMapKey("it").AtMapKey("entity_type"), knownvalue.StringExact("1")),
+					statecheck.ExpectKnownValue("data.autodns_contact.test", tfjsonpath.New("email"), knownvalue.StringExact("jane@acctest.dev")),
+				},
+				Check: resource.TestCheckResourceAttrPair("data.autodns_contact.test", "id", "autodns_contact.test", "id"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "autodns_contact.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccContactResourceConfig("doe@acctest.dev"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_contact.test", tfjsonpath.New("email"), knownvalue.StringExact("doe@acctest.dev")),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
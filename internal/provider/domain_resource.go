@@ -0,0 +1,488 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &DomainResource{}
+	_ resource.ResourceWithConfigure      = &DomainResource{}
+	_ resource.ResourceWithImportState    = &DomainResource{}
+	_ resource.ResourceWithValidateConfig = &DomainResource{}
+)
+
+func NewDomainResource() resource.Resource {
+	return &DomainResource{}
+}
+
+// DomainResource defines the resource implementation.
+type DomainResource struct {
+	client *api.Client
+}
+
+// DomainResourceModel describes the resource data model.
+type DomainResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	NameServers  types.List   `tfsdk:"name_servers"`
+	DNSSECKeys   types.List   `tfsdk:"dnssec_keys"`
+	AutoRenew    types.Bool   `tfsdk:"auto_renew"`
+	TransferLock types.Bool   `tfsdk:"transfer_lock"`
+	OwnerContact types.String `tfsdk:"owner_contact"`
+	AdminContact types.String `tfsdk:"admin_contact"`
+	TechContact  types.String `tfsdk:"tech_contact"`
+	ZoneContact  types.String `tfsdk:"zone_contact"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// DomainDNSSECKeyModel describes a DNSSEC key of the domain.
+type DomainDNSSECKeyModel struct {
+	Flags     types.Int64  `tfsdk:"flags"`
+	Protocol  types.Int64  `tfsdk:"protocol"`
+	Algorithm types.Int64  `tfsdk:"algorithm"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+var domainDNSSECKeyAttrTypes = map[string]attr.Type{
+	"flags":      types.Int64Type,
+	"protocol":   types.Int64Type,
+	"algorithm":  types.Int64Type,
+	"public_key": types.StringType,
+}
+
+// domainContacts are the contact attributes of the resource.
+var domainContacts = []string{"owner_contact", "admin_contact", "tech_contact", "zone_contact"}
+
+func (r *DomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage the delegation and the registrar settings of a domain registered with AutoDNS. " +
+			"The domain must already be registered, creating the resource adopts it. " +
+			"Destroying the resource only removes it from the terraform state, the domain is neither cancelled nor changed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The domain name.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the registered domain, e.g. example.com.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_servers": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The nameservers the domain is delegated to.",
+				Required:            true,
+			},
+			"dnssec_keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The DNSSEC keys whose DS records are published by the registry, e.g. the key signing keys of `autodns_zone_dnssec`. " +
+					"An empty list removes the DS records. Defaults to the keys already configured for the domain.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"flags": schema.Int64Attribute{
+							MarkdownDescription: "Flags of the key, 257 for key signing keys.",
+							Required:            true,
+						},
+						"protocol": schema.Int64Attribute{
+							MarkdownDescription: "Protocol of the key. Defaults to `3`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(3),
+						},
+						"algorithm": schema.Int64Attribute{
+							MarkdownDescription: "Algorithm of the key, e.g. 13 for ECDSA P-256 with SHA-256.",
+							Required:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "Base64 encoded public key.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"auto_renew": schema.BoolAttribute{
+				MarkdownDescription: "Whether the domain is renewed automatically. Defaults to the current setting of the domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"transfer_lock": schema.BoolAttribute{
+				MarkdownDescription: "Whether the domain is locked against transfers to another registrar. Defaults to the current setting of the domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner_contact": schema.StringAttribute{
+				MarkdownDescription: "ID of the contact handle of the domain owner. Defaults to the current contact of the domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_contact": schema.StringAttribute{
+				MarkdownDescription: "ID of the contact handle of the administrative contact. Defaults to the current contact of the domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tech_contact": schema.StringAttribute{
+				MarkdownDescription: "ID of the contact handle of the technical contact. Defaults to the current contact of the domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_contact": schema.StringAttribute{
+				MarkdownDescription: "ID of the contact handle of the zone contact. Defaults to the current contact of the domain.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *DomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DomainResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	// The domain is registered outside of terraform, the resource adopts it
+	domain, err := r.client.GetDomain(ctx, plan.Name.ValueString())
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the domain", err, path.Root("name"), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(expandDomain(ctx, plan, domain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	domain, err = r.client.UpdateDomain(ctx, domain)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the domain", err, path.Root("name"), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenDomain(ctx, domain, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DomainResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	domain, err := r.client.GetDomain(ctx, state.Name.ValueString())
+	if api.IsNotFound(err) {
+		// The domain has been cancelled or transferred away
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the domain", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenDomain(ctx, domain, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DomainResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	// The update replaces all settings, so start from the current domain
+	domain, err := r.client.GetDomain(ctx, plan.Name.ValueString())
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the domain", err, path.Root("name"), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(expandDomain(ctx, plan, domain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	domain, err = r.client.UpdateDomain(ctx, domain)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the domain", err, path.Root("name"), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenDomain(ctx, domain, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The domain stays registered with its current settings, cancelling it can't be undone
+	tflog.Trace(ctx, "removed the domain from the state")
+}
+
+func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: DOMAIN. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+func (r *DomainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DomainResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contacts := []types.String{config.OwnerContact, config.AdminContact, config.TechContact, config.ZoneContact}
+	for i, contact := range contacts {
+		parseContactID(&resp.Diagnostics, path.Root(domainContacts[i]), contact)
+	}
+
+	// Skip when the nameservers are still unknown to terraform
+	if config.NameServers.IsUnknown() {
+		return
+	}
+
+	if len(config.NameServers.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_servers"),
+			"Wrong Attribute Configuration",
+			"At least one nameserver must be set for the domain.",
+		)
+	}
+}
+
+// parseContactID parses the contact handle ID of the attribute, adding an attribute error when it's not a number.
+// Null and unknown values are returned as nil.
+func parseContactID(diags *diag.Diagnostics, p path.Path, value types.String) *api.ContactRef {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(value.ValueString(), 10, 64)
+	if err != nil || id <= 0 {
+		diags.AddAttributeError(
+			p,
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("Value is not the ID of a contact handle: %s", value.ValueString()),
+		)
+		return nil
+	}
+
+	return &api.ContactRef{ID: id}
+}
+
+// expandDomain applies the settings from the model on top of the given domain.
+// Settings which are unknown in the model are left as they are.
+func expandDomain(ctx context.Context, model DomainResourceModel, domain *api.Domain) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	nameServers := make([]string, 0, len(model.NameServers.Elements()))
+	diags.Append(model.NameServers.ElementsAs(ctx, &nameServers, false)...)
+
+	domain.NameServers = []api.NameServer{}
+	for _, ns := range nameServers {
+		domain.NameServers = append(domain.NameServers, api.NameServer{Name: ns})
+	}
+
+	if !model.DNSSECKeys.IsUnknown() {
+		keys := []DomainDNSSECKeyModel{}
+		diags.Append(model.DNSSECKeys.ElementsAs(ctx, &keys, true)...)
+
+		domain.DNSSECData = []api.DNSKey{}
+		for _, key := range keys {
+			domain.DNSSECData = append(domain.DNSSECData, api.DNSKey{
+				Flags:     int(key.Flags.ValueInt64()),
+				Protocol:  int(key.Protocol.ValueInt64()),
+				Algorithm: int(key.Algorithm.ValueInt64()),
+				PublicKey: key.PublicKey.ValueString(),
+			})
+		}
+	}
+
+	if !model.AutoRenew.IsUnknown() {
+		domain.AutoRenewStatus = api.AutoRenewStatusFalse
+		if model.AutoRenew.ValueBool() {
+			domain.AutoRenewStatus = api.AutoRenewStatusTrue
+		}
+	}
+
+	if !model.TransferLock.IsUnknown() {
+		domain.RegistrarStatus = api.RegistrarStatusActive
+		if model.TransferLock.ValueBool() {
+			domain.RegistrarStatus = api.RegistrarStatusLock
+		}
+	}
+
+	contacts := []types.String{model.OwnerContact, model.AdminContact, model.TechContact, model.ZoneContact}
+	refs := []**api.ContactRef{&domain.OwnerC, &domain.AdminC, &domain.TechC, &domain.ZoneC}
+	for i, contact := range contacts {
+		if ref := parseContactID(&diags, path.Root(domainContacts[i]), contact); ref != nil {
+			*refs[i] = ref
+		}
+	}
+
+	return diags
+}
+
+// flattenDomain maps the domain returned by the API into the model.
+func flattenDomain(ctx context.Context, domain *api.Domain, model *DomainResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(domain.Name)
+	model.Name = types.StringValue(domain.Name)
+	model.AutoRenew = types.BoolValue(domain.AutoRenewStatus == api.AutoRenewStatusTrue)
+	model.TransferLock = types.BoolValue(domain.RegistrarStatus == api.RegistrarStatusLock)
+
+	nameServers := []string{}
+	for _, ns := range domain.NameServers {
+		nameServers = append(nameServers, ns.Name)
+	}
+
+	tfNameServers, d := types.ListValueFrom(ctx, types.StringType, nameServers)
+	diags.Append(d...)
+	model.NameServers = tfNameServers
+
+	keys := []DomainDNSSECKeyModel{}
+	for _, key := range domain.DNSSECData {
+		keys = append(keys, DomainDNSSECKeyModel{
+			Flags:     types.Int64Value(int64(key.Flags)),
+			Protocol:  types.Int64Value(int64(key.Protocol)),
+			Algorithm: types.Int64Value(int64(key.Algorithm)),
+			PublicKey: types.StringValue(key.PublicKey),
+		})
+	}
+
+	tfKeys, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: domainDNSSECKeyAttrTypes}, keys)
+	diags.Append(d...)
+	model.DNSSECKeys = tfKeys
+
+	model.OwnerContact = flattenContactRef(domain.OwnerC)
+	model.AdminContact = flattenContactRef(domain.AdminC)
+	model.TechContact = flattenContactRef(domain.TechC)
+	model.ZoneContact = flattenContactRef(domain.ZoneC)
+
+	return diags
+}
+
+// flattenContactRef returns the ID of the contact handle, null when the domain has no such contact.
+func flattenContactRef(ref *api.ContactRef) types.String {
+	if ref == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(strconv.FormatInt(ref.ID, 10))
+}
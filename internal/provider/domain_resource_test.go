@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var domainName = testAccEnv("TF_AUTODNS_DOMAIN", testAccMockDomain.Name)
+
+func testAccDomainResourceConfig(nameServers string, transferLock bool) string {
+	return fmt.Sprintf(`
+resource "autodns_domain" "test" {
+  name          = %q
+  name_servers  = %s
+  auto_renew    = true
+  transfer_lock = %t
+
+  dnssec_keys = [
+    {
+      flags      = 257
+      algorithm  = 13
+      public_key = "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="
+    },
+  ]
+}
+`, domainName, nameServers, transferLock)
+}
+
+func TestAccDomainResource(t *testing.T) {
+	if testAccLive && os.Getenv("TF_AUTODNS_DOMAIN") == "" {
+		t.Skip("TF_AUTODNS_DOMAIN must be set to a registered domain for domain_resource tests to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDomainResourceConfig(`["a.ns14.net", "b.ns14.net"]`, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_domain.test", tfjsonpath.New("id"), knownvalue.StringExact(domainName)),
+					statecheck.ExpectKnownValue("autodns_domain.test", tfjsonpath.New("transfer_lock"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("autodns_domain.test", tfjsonpath.New("dnssec_keys").AtSliceIndex(0).AtMapKey("protocol"), knownvalue.Int64Exact(3)),
+					statecheck.ExpectKnownValue("autodns_domain.test", tfjsonpath.New("owner_contact"), knownvalue.NotNull()),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "autodns_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccDomainResourceConfig(`["a.ns14.net", "b.ns14.net", "c.ns14.net"]`, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_domain.test", tfjsonpath.New("name_servers"), knownvalue.ListSizeExact(3)),
+					statecheck.ExpectKnownValue("autodns_domain.test", tfjsonpath.New("transfer_lock"), knownvalue.Bool(true)),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// appendClientError adds a diagnostic for an error returned by the API client.
+// Errors for missing objects are reported on the notFound attribute and errors
+// for rejected payloads on the invalid attribute, unless the paths are empty.
+func appendClientError(diags *diag.Diagnostics, message string, err error, notFound, invalid path.Path) {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		diags.AddError("Client Error", fmt.Sprintf("%s, got error:\n %s", message, err))
+		return
+	}
+
+	switch {
+	case apiErr.IsAuthFailure():
+		diags.AddError(
+			"AutoDNS Authentication Failed",
+			fmt.Sprintf("%s, the credentials were rejected by AutoDNS. "+
+				"Please check the username, password and context of the provider configuration.\n\n%s", message, describeAPIError(apiErr)),
+		)
+	case apiErr.IsRateLimited():
+		diags.AddError(
+			"AutoDNS Rate Limit Exceeded",
+			fmt.Sprintf("%s, too many requests have been sent to AutoDNS. Please try again later.\n\n%s", message, describeAPIError(apiErr)),
+		)
+	case apiErr.IsNotFound() && len(notFound.Steps()) != 0:
+		diags.AddAttributeError(
+			notFound,
+			"AutoDNS Object Not Found",
+			fmt.Sprintf("%s, the object does not exist in AutoDNS.\n\n%s", message, describeAPIError(apiErr)),
+		)
+	case apiErr.IsValidation() && len(invalid.Steps()) != 0:
+		diags.AddAttributeError(
+			invalid,
+			"AutoDNS Validation Error",
+			fmt.Sprintf("%s, the request was rejected by AutoDNS.\n\n%s", message, describeAPIError(apiErr)),
+		)
+	default:
+		diags.AddError("Client Error", fmt.Sprintf("%s, got error:\n\n%s", message, describeAPIError(apiErr)))
+	}
+}
+
+// describeAPIError renders the status and messages of the API error as readable text.
+func describeAPIError(err *api.Error) string {
+	texts := err.MessageTexts()
+	if err.Status.Text == "" && len(texts) == 0 {
+		return err.Error()
+	}
+
+	lines := []string{}
+	if err.Status.Text != "" && err.Status.Code != "" {
+		lines = append(lines, fmt.Sprintf("%s (%s)", err.Status.Text, err.Status.Code))
+	} else if err.Status.Text != "" {
+		lines = append(lines, err.Status.Text)
+	}
+
+	for _, t := range texts {
+		lines = append(lines, "- "+t)
+	}
+
+	return strings.Join(lines, "\n")
+}
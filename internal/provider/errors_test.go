@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAppendClientError(t *testing.T) {
+	notFound, invalid := path.Root("zone_id"), path.Root("records")
+
+	apiError := func(statusCode int) error {
+		return fmt.Errorf("request failed: %w", &api.Error{
+			StatusCode: statusCode,
+			Status:     api.ResponseStatus{Code: "EF02020", Text: "The zone data is invalid."},
+			Messages:   []api.Message{{Text: "The record value is missing.", Code: "EF02020"}},
+		})
+	}
+
+	tests := []struct {
+		name             string
+		err              error
+		notFound         path.Path
+		invalid          path.Path
+		wantSummary      string
+		wantPath         path.Path
+		wantDetailSubstr string
+	}{
+		{name: "network error", err: errors.New("connection refused"), notFound: notFound, invalid: invalid, wantSummary: "Client Error", wantDetailSubstr: "connection refused"},
+		{name: "auth failure", err: apiError(401), notFound: notFound, invalid: invalid, wantSummary: "AutoDNS Authentication Failed", wantDetailSubstr: "credentials were rejected"},
+		{name: "rate limited", err: apiError(429), notFound: notFound, invalid: invalid, wantSummary: "AutoDNS Rate Limit Exceeded", wantDetailSubstr: "too many requests"},
+		{name: "not found", err: apiError(404), notFound: notFound, invalid: invalid, wantSummary: "AutoDNS Object Not Found", wantPath: notFound},
+		{name: "not found without path", err: apiError(404), notFound: path.Empty(), invalid: invalid, wantSummary: "Client Error"},
+		{name: "validation", err: apiError(400), notFound: notFound, invalid: invalid, wantSummary: "AutoDNS Validation Error", wantPath: invalid, wantDetailSubstr: "- The record value is missing. (EF02020)"},
+		{name: "validation without path", err: apiError(400), notFound: notFound, invalid: path.Empty(), wantSummary: "Client Error", wantDetailSubstr: "The zone data is invalid. (EF02020)"},
+		{name: "server error", err: apiError(500), notFound: notFound, invalid: invalid, wantSummary: "Client Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			appendClientError(&diags, "Unable to update the zone", tt.err, tt.notFound, tt.invalid)
+
+			if len(diags) != 1 {
+				t.Fatalf("appendClientError() added %d diagnostics, want 1", len(diags))
+			}
+
+			d := diags[0]
+			if d.Summary() != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", d.Summary(), tt.wantSummary)
+			}
+
+			if !strings.HasPrefix(d.Detail(), "Unable to update the zone") || !strings.Contains(d.Detail(), tt.wantDetailSubstr) {
+				t.Errorf("detail = %q, want the message and %q", d.Detail(), tt.wantDetailSubstr)
+			}
+
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if ok != (len(tt.wantPath.Steps()) != 0) {
+				t.Fatalf("diagnostic with path = %t, want %t", ok, len(tt.wantPath.Steps()) != 0)
+			}
+
+			if ok && !withPath.Path().Equal(tt.wantPath) {
+				t.Errorf("path = %s, want %s", withPath.Path(), tt.wantPath)
+			}
+		})
+	}
+}
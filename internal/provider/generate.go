@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"unicode"
+)
+
+// GenerateRecordConfig renders an autodns_record resource with a matching import block for every name and type of the records,
+// so existing zones can be brought under terraform management. The records are grouped like the resource reads them.
+func GenerateRecordConfig(ctx context.Context, zoneID api.ZoneID, records []api.Record) ([]byte, error) {
+	// Group the records by name and type, keeping the order of their values
+	groups := map[[2]string][]api.Record{}
+	keys := [][2]string{}
+	for _, r := range records {
+		key := [2]string{r.Name, r.Type}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], r)
+	}
+
+	slices.SortFunc(keys, func(a, b [2]string) int {
+		return cmp.Or(strings.Compare(a[0], b[0]), strings.Compare(a[1], b[1]))
+	})
+
+	var b strings.Builder
+	used := map[string]bool{}
+
+	for i, key := range keys {
+		model, diags := flattenRecord(ctx, groups[key], false)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to convert the %s records of %q: %s", key[1], key[0], diags.Errors()[0].Detail())
+		}
+
+		values := []string{}
+		diags = model.Values.ElementsAs(ctx, &values, false)
+		if diags.HasError() {
+			return nil, errors.New(diags.Errors()[0].Detail())
+		}
+
+		name := resourceName(key[0], key[1], used)
+		id := zoneID.String() + "__" + key[0] + "__" + key[1]
+
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "import {\n  to = autodns_record.%s\n  id = %s\n}\n\n", name, hclString(id))
+		fmt.Fprintf(&b, "resource \"autodns_record\" %q {\n", name)
+		fmt.Fprintf(&b, "  zone_id = %s\n", hclString(zoneID.String()))
+		fmt.Fprintf(&b, "  name    = %s\n", hclString(model.Name.ValueString()))
+		fmt.Fprintf(&b, "  type    = %s\n", hclString(model.Type.ValueString()))
+		fmt.Fprintf(&b, "  ttl     = %d\n", model.TTL.ValueInt64())
+
+		if len(values) == 1 {
+			fmt.Fprintf(&b, "  values  = [%s]\n", hclString(values[0]))
+		} else {
+			b.WriteString("  values  = [\n")
+			for _, v := range values {
+				fmt.Fprintf(&b, "    %s,\n", hclString(v))
+			}
+			b.WriteString("  ]\n")
+		}
+
+		b.WriteString("}\n")
+	}
+
+	return []byte(b.String()), nil
+}
+
+// resourceName returns a unique terraform resource name for the records with the name and type.
+func resourceName(name, recordType string, used map[string]bool) string {
+	if name == "" {
+		name = "apex"
+	}
+
+	var b strings.Builder
+	for _, c := range strings.ToLower(name + "_" + recordType) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' || c == '-' {
+			b.WriteRune(c)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	// Names must start with a letter or an underscore
+	base := b.String()
+	if base[0] >= '0' && base[0] <= '9' || base[0] == '-' {
+		base = "_" + base
+	}
+
+	unique := base
+	for i := 2; used[unique]; i++ {
+		unique = base + "_" + strconv.Itoa(i)
+	}
+	used[unique] = true
+
+	return unique
+}
+
+// hclString quotes the string for HCL, escaping template sequences so values are taken literally.
+// HCL only knows a few of the Go escapes, other non-printable characters are written as \uNNNN.
+// Invalid UTF-8 can't be represented in HCL and is replaced with U+FFFD.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			// ${ and %{ start template sequences, doubling the first character escapes them
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r):
+			if r > 0xFFFF {
+				fmt.Fprintf(&b, `\U%08X`, r)
+			} else {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,27 @@
+package provider
+
+import "testing"
+
+func TestHCLString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: `""`},
+		{in: "v=spf1 -all", want: `"v=spf1 -all"`},
+		{in: `say "hi" \o/`, want: `"say \"hi\" \\o/"`},
+		{in: "a\tb\r\nc", want: `"a\tb\r\nc"`},
+		// Go escapes like \x00, \a and \v aren't valid in HCL
+		{in: "\x00\a\v\x7f", want: `"\u0000\u0007\u000B\u007F"`},
+		{in: "ü\u00a0\U000e0001", want: `"ü\u00A0\U000E0001"`},
+		{in: "\xff", want: `"` + "\uFFFD" + `"`},
+		{in: "${var} %{if} $${x}", want: `"$${var} %%{if} $$${x}"`},
+		{in: "100% $5", want: `"100% $5"`},
+	}
+
+	for _, tt := range tests {
+		if got := hclString(tt.in); got != tt.want {
+			t.Errorf("hclString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
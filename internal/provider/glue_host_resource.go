@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &GlueHostResource{}
+	_ resource.ResourceWithConfigure      = &GlueHostResource{}
+	_ resource.ResourceWithImportState    = &GlueHostResource{}
+	_ resource.ResourceWithValidateConfig = &GlueHostResource{}
+)
+
+func NewGlueHostResource() resource.Resource {
+	return &GlueHostResource{}
+}
+
+// GlueHostResource defines the resource implementation.
+type GlueHostResource struct {
+	client *api.Client
+}
+
+// GlueHostResourceModel describes the resource data model.
+type GlueHostResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	IPv4Addresses types.List   `tfsdk:"ipv4_addresses"`
+	IPv6Addresses types.List   `tfsdk:"ipv6_addresses"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (r *GlueHostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_glue_host"
+}
+
+func (r *GlueHostResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage the glue records of a nameserver below a domain of the account, e.g. ns1.example.com for a vanity nameserver. " +
+			"AutoDNS registers the host with its IP addresses at the registry of the domain.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The host name.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The host name of the nameserver, it must be below a domain of the account.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv4_addresses": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IPv4 addresses of the nameserver. At least one IPv4 or IPv6 address must be set.",
+				Optional:            true,
+			},
+			"ipv6_addresses": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IPv6 addresses of the nameserver. At least one IPv4 or IPv6 address must be set.",
+				Optional:            true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *GlueHostResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GlueHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GlueHostResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	host := &api.Host{}
+	resp.Diagnostics.Append(expandGlueHost(ctx, plan, host)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	host, err := r.client.CreateHost(ctx, host)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to create the host", err, path.Empty(), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenGlueHost(ctx, host, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GlueHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GlueHostResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	host, err := r.client.GetHost(ctx, state.ID.ValueString())
+	if api.IsNotFound(err) {
+		// The host has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the host", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenGlueHost(ctx, host, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *GlueHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GlueHostResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	host := &api.Host{}
+	resp.Diagnostics.Append(expandGlueHost(ctx, plan, host)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	host, err := r.client.UpdateHost(ctx, host)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the host", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenGlueHost(ctx, host, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GlueHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GlueHostResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	// API call to delete the host, there's nothing left to do when it's gone
+	err := r.client.DeleteHost(ctx, state.ID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to delete the host", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *GlueHostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !strings.Contains(req.ID, ".") || strings.ContainsAny(req.ID, "/@ ") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: HOST_NAME. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+func (r *GlueHostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config GlueHostResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip when the addresses are still unknown to terraform
+	if config.IPv4Addresses.IsUnknown() || config.IPv6Addresses.IsUnknown() {
+		return
+	}
+
+	if len(config.IPv4Addresses.Elements()) == 0 && len(config.IPv6Addresses.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ipv4_addresses"),
+			"Wrong Attribute Configuration",
+			"At least one IPv4 or IPv6 address of the host must be set.",
+		)
+		return
+	}
+
+	for name, list := range map[string]types.List{"ipv4_addresses": config.IPv4Addresses, "ipv6_addresses": config.IPv6Addresses} {
+		for i, v := range list.Elements() {
+			s, ok := v.(types.String)
+			if !ok || s.IsUnknown() {
+				continue
+			}
+
+			ip := net.ParseIP(s.ValueString())
+			if ip == nil || (ip.To4() != nil) != (name == "ipv4_addresses") {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name).AtListIndex(i),
+					"Wrong Attribute Configuration",
+					fmt.Sprintf("Value is not a valid %s address: %s", strings.ToUpper(strings.TrimSuffix(name, "_addresses")), s.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+// expandGlueHost applies the host settings from the model on top of the given host.
+func expandGlueHost(ctx context.Context, model GlueHostResourceModel, host *api.Host) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ipv4, ipv6 := []string{}, []string{}
+	diags.Append(model.IPv4Addresses.ElementsAs(ctx, &ipv4, true)...)
+	diags.Append(model.IPv6Addresses.ElementsAs(ctx, &ipv6, true)...)
+
+	host.Name = model.Name.ValueString()
+	host.IPAddresses = append(ipv4, ipv6...)
+
+	return diags
+}
+
+// flattenGlueHost maps the host returned by the API into the model.
+func flattenGlueHost(ctx context.Context, host *api.Host, model *GlueHostResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ipv4, ipv6 := []string{}, []string{}
+	for _, address := range host.IPAddresses {
+		if ip := net.ParseIP(address); ip != nil && ip.To4() != nil {
+			ipv4 = append(ipv4, address)
+		} else {
+			ipv6 = append(ipv6, address)
+		}
+	}
+
+	model.ID = types.StringValue(host.Name)
+	model.Name = types.StringValue(host.Name)
+	model.IPv4Addresses = optionalList(ctx, &diags, ipv4, model.IPv4Addresses)
+	model.IPv6Addresses = optionalList(ctx, &diags, ipv6, model.IPv6Addresses)
+
+	return diags
+}
+
+// optionalList returns the values as list, or null when there are none and the current list is null.
+func optionalList(ctx context.Context, diags *diag.Diagnostics, values []string, current types.List) types.List {
+	if len(values) == 0 && current.IsNull() {
+		return types.ListNull(types.StringType)
+	}
+
+	list, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+
+	return list
+}
@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &NameServerGroupResource{}
+	_ resource.ResourceWithConfigure      = &NameServerGroupResource{}
+	_ resource.ResourceWithImportState    = &NameServerGroupResource{}
+	_ resource.ResourceWithValidateConfig = &NameServerGroupResource{}
+)
+
+func NewNameServerGroupResource() resource.Resource {
+	return &NameServerGroupResource{}
+}
+
+// NameServerGroupResource defines the resource implementation.
+type NameServerGroupResource struct {
+	client *api.Client
+}
+
+// NameServerGroupResourceModel describes the resource data model.
+type NameServerGroupResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	NameServers types.List   `tfsdk:"name_servers"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (r *NameServerGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_name_server_group"
+}
+
+func (r *NameServerGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage a custom nameserver group, e.g. for white-label DNS with vanity nameservers. " +
+			"Zones are assigned to the group with the `name_server_group` attribute of `autodns_zone`. " +
+			"Nameservers below a domain of the account need an `autodns_glue_host` with their IP addresses.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_servers": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The host names of the nameservers of the group, at least two are required.",
+				Required:            true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *NameServerGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NameServerGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NameServerGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	group := &api.NameServerGroup{}
+	resp.Diagnostics.Append(expandNameServerGroup(ctx, plan, group)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	group, err := r.client.CreateNameServerGroup(ctx, group)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to create the nameserver group", err, path.Empty(), path.Root("name_servers"))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenNameServerGroup(ctx, group, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NameServerGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NameServerGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	group, err := r.client.GetNameServerGroup(ctx, state.ID.ValueString())
+	if api.IsNotFound(err) {
+		// The group has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the nameserver group", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenNameServerGroup(ctx, group, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *NameServerGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NameServerGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	group := &api.NameServerGroup{}
+	resp.Diagnostics.Append(expandNameServerGroup(ctx, plan, group)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	group, err := r.client.UpdateNameServerGroup(ctx, group)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the nameserver group", err, path.Empty(), path.Root("name_servers"))
+		return
+	}
+
+	resp.Diagnostics.Append(flattenNameServerGroup(ctx, group, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NameServerGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NameServerGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	// API call to delete the group, there's nothing left to do when it's gone
+	err := r.client.DeleteNameServerGroup(ctx, state.ID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to delete the nameserver group", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *NameServerGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" || strings.ContainsAny(req.ID, "/@ ") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: GROUP_NAME. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+func (r *NameServerGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config NameServerGroupResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip when the nameservers are still unknown to terraform
+	if config.NameServers.IsUnknown() {
+		return
+	}
+
+	if len(config.NameServers.Elements()) < 2 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_servers"),
+			"Wrong Attribute Configuration",
+			"At least two nameservers of the group must be set.",
+		)
+	}
+}
+
+// expandNameServerGroup applies the group settings from the model on top of the given group.
+func expandNameServerGroup(ctx context.Context, model NameServerGroupResourceModel, group *api.NameServerGroup) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	nameServers := make([]string, 0, len(model.NameServers.Elements()))
+	diags.Append(model.NameServers.ElementsAs(ctx, &nameServers, false)...)
+
+	group.Name = model.Name.ValueString()
+	group.NameServers = []api.NameServer{}
+	for _, ns := range nameServers {
+		group.NameServers = append(group.NameServers, api.NameServer{Name: ns})
+	}
+
+	return diags
+}
+
+// flattenNameServerGroup maps the group returned by the API into the model.
+func flattenNameServerGroup(ctx context.Context, group *api.NameServerGroup, model *NameServerGroupResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	nameServers := []string{}
+	for _, ns := range group.NameServers {
+		nameServers = append(nameServers, ns.Name)
+	}
+
+	tfNameServers, d := types.ListValueFrom(ctx, types.StringType, nameServers)
+	diags.Append(d...)
+
+	model.ID = types.StringValue(group.Name)
+	model.Name = types.StringValue(group.Name)
+	model.NameServers = tfNameServers
+
+	return diags
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testNameServerGroup = "acctest-" + acctest.RandString(8)
+
+var testNameServerGroupZoneOrigin = "acctest-" + acctest.RandString(8) + ".dev"
+
+func testAccNameServerGroupResourceConfig(ns1Address string, assignGroup bool) string {
+	zoneGroup := "null"
+	if assignGroup {
+		zoneGroup = "autodns_name_server_group.test.name"
+	}
+
+	return fmt.Sprintf(`
+resource "autodns_glue_host" "ns1" {
+  name           = "ns1-%[1]s.%[2]s"
+  ipv4_addresses = [%[3]q]
+  ipv6_addresses = ["2001:db8::1"]
+}
+
+resource "autodns_glue_host" "ns2" {
+  name           = "ns2-%[1]s.%[2]s"
+  ipv4_addresses = ["192.0.2.2"]
+}
+
+resource "autodns_name_server_group" "test" {
+  name         = %[1]q
+  name_servers = [autodns_glue_host.ns1.name, autodns_glue_host.ns2.name]
+}
+
+resource "autodns_zone" "test" {
+  origin              = %[4]q
+  virtual_name_server = "a.ns14.net"
+  name_server_group   = %[5]s
+  name_servers        = autodns_name_server_group.test.name_servers
+}
+`, testNameServerGroup, domainName, ns1Address, testNameServerGroupZoneOrigin, zoneGroup)
+}
+
+func TestAccNameServerGroupResource(t *testing.T) {
+	if testAccLive && os.Getenv("TF_AUTODNS_DOMAIN") == "" {
+		t.Skip("TF_AUTODNS_DOMAIN must be set to a registered domain for name_server_group_resource tests to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNameServerGroupResourceConfig("192.0.2.1", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_glue_host.ns1", tfjsonpath.New("ipv6_addresses"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("2001:db8::1"),
+					})),
+					statecheck.ExpectKnownValue("autodns_glue_host.ns2", tfjsonpath.New("ipv6_addresses"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("autodns_name_server_group.test", tfjsonpath.New("name_servers"), knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("name_server_group"), knownvalue.StringExact(testNameServerGroup)),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "autodns_glue_host.ns1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "autodns_name_server_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNameServerGroupResourceConfig("198.51.100.1", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_glue_host.ns1", tfjsonpath.New("ipv4_addresses").AtSliceIndex(0), knownvalue.StringExact("198.51.100.1")),
+				},
+			},
+			// Removing the group from the zone moves it back to the group of the virtual name server
+			{
+				Config: testAccNameServerGroupResourceConfig("198.51.100.1", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("name_server_group"), knownvalue.StringExact("ns14.net")),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
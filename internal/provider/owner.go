@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-autodns/internal/api"
+
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	ownerUserDescription = "The subuser the requests for this resource are sent on behalf of, overriding the `owner_user` of the provider. " +
+		"Imports use the owner of the provider."
+	ownerContextDescription = "The context of the subuser the requests for this resource are sent on behalf of, " +
+		"overriding the `owner_context` of the provider."
+
+	ownerUserDataSourceDescription = "The subuser the requests for this data source are sent on behalf of, " +
+		"overriding the `owner_user` of the provider."
+	ownerContextDataSourceDescription = "The context of the subuser the requests for this data source are sent on behalf of, " +
+		"overriding the `owner_context` of the provider."
+)
+
+// ownerResourceAttributes returns the attributes of a resource overriding the owner of the provider.
+func ownerResourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"owner_user": schema.StringAttribute{
+			MarkdownDescription: ownerUserDescription,
+			Optional:            true,
+		},
+		"owner_context": schema.StringAttribute{
+			MarkdownDescription: ownerContextDescription,
+			Optional:            true,
+		},
+	}
+}
+
+// ownerDataSourceAttributes returns the attributes of a data source overriding the owner of the provider.
+func ownerDataSourceAttributes() map[string]dsschema.Attribute {
+	return map[string]dsschema.Attribute{
+		"owner_user": dsschema.StringAttribute{
+			MarkdownDescription: ownerUserDataSourceDescription,
+			Optional:            true,
+		},
+		"owner_context": dsschema.StringAttribute{
+			MarkdownDescription: ownerContextDataSourceDescription,
+			Optional:            true,
+		},
+	}
+}
+
+// withOwner returns a context sending the requests on behalf of the owner configured for the resource.
+func withOwner(ctx context.Context, user, ownerContext types.String) context.Context {
+	return api.WithOwner(ctx, api.Owner{
+		User:    user.ValueString(),
+		Context: ownerContext.ValueString(),
+	})
+}
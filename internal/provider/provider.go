@@ -2,10 +2,16 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"sync"
 	"terraform-provider-autodns/internal/api"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -37,6 +43,21 @@ type AutoDNSProviderModel struct {
 	Context  types.String `tfsdk:"context"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+
+	TOTPSecret types.String `tfsdk:"totp_secret"`
+	AuthMode   types.String `tfsdk:"auth_mode"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	DisableZoneCache      types.Bool  `tfsdk:"disable_zone_cache"`
+
+	BatchWindow types.String `tfsdk:"batch_window"`
 }
 
 func (p *AutoDNSProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,7 +70,7 @@ func (p *AutoDNSProvider) Schema(ctx context.Context, req provider.SchemaRequest
 		Description: "Interact with AutoDNS API.",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "AutoDNS api endpoint. May also be provided via AUTODNS_ENDPOINT environment variable.",
+				MarkdownDescription: "AutoDNS api endpoint, reached via https unless it starts with a scheme. May also be provided via AUTODNS_ENDPOINT environment variable.",
 				Optional:            true,
 			},
 			"context": schema.StringAttribute{
@@ -67,6 +88,60 @@ func (p *AutoDNSProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"totp_secret": schema.StringAttribute{
+				MarkdownDescription: "Base32 encoded TOTP secret of AutoDNS users with two-factor authentication. " +
+					"The one-time code is generated for every request, so long applies keep working when the code rolls over. " +
+					"May also be provided via AUTODNS_TOTP_SECRET environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"auth_mode": schema.StringAttribute{
+				MarkdownDescription: "How the provider authenticates with AutoDNS. \"basic\" sends the credentials with every request, " +
+					"\"session\" logs in once, logs in again when the session expires and logs out when the provider shuts down. " +
+					"The logout is best effort, sessions left behind when Terraform stops the provider expire on the AutoDNS side. " +
+					"Defaults to \"basic\". May also be provided via AUTODNS_AUTH_MODE environment variable.",
+				Optional: true,
+			},
+			"owner_user": schema.StringAttribute{
+				MarkdownDescription: "The subuser all requests are sent on behalf of, so reseller credentials can manage the zones of a customer. " +
+					"Resources can override it with their own `owner_user`. May also be provided via AUTODNS_OWNER_USER environment variable.",
+				Optional: true,
+			},
+			"owner_context": schema.StringAttribute{
+				MarkdownDescription: "The context of the subuser all requests are sent on behalf of. " +
+					"Resources can override it with their own `owner_context`. May also be provided via AUTODNS_OWNER_CONTEXT environment variable.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for requests failing with a network error, rate limiting or a server error. " +
+					"Defaults to 3. May also be provided via AUTODNS_MAX_RETRIES environment variable.",
+				Optional: true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Initial backoff between retries as a duration, e.g. \"1s\". Doubles with every retry. " +
+					"Defaults to \"1s\". May also be provided via AUTODNS_RETRY_WAIT_MIN environment variable.",
+				Optional: true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum backoff between retries as a duration, e.g. \"30s\". Longer Retry-After headers of the API are capped to it. " +
+					"Defaults to \"30s\". May also be provided via AUTODNS_RETRY_WAIT_MAX environment variable.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the AutoDNS API in parallel. Writes to the same zone are always sent one at a time. " +
+					"Defaults to 5. May also be provided via AUTODNS_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional: true,
+			},
+			"disable_zone_cache": schema.BoolAttribute{
+				MarkdownDescription: "Fetch the zone from the API for every read instead of sharing it between the resources of the zone. " +
+					"Useful for debugging. May also be provided via AUTODNS_DISABLE_ZONE_CACHE environment variable.",
+				Optional: true,
+			},
+			"batch_window": schema.StringAttribute{
+				MarkdownDescription: "How long record changes to the same zone are collected to be sent in a single request, as a duration, e.g. \"200ms\". " +
+					"Set to \"0s\" to send every change on its own. Defaults to \"200ms\". May also be provided via AUTODNS_BATCH_WINDOW environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -78,39 +153,136 @@ func (p *AutoDNSProvider) Configure(ctx context.Context, req provider.ConfigureR
 	tflog.Info(ctx, "configuring AutoDNS client")
 
 	// Get values from environment variables
-	endpoint := os.Getenv("AUTODNS_ENDPOINT")
-	context := os.Getenv("AUTODNS_CONTEXT")
-	username := os.Getenv("AUTODNS_USERNAME")
-	password := os.Getenv("AUTODNS_PASSWORD")
+	settings := clientSettingsFromEnv()
 
 	if !config.Endpoint.IsNull() {
-		endpoint = config.Endpoint.ValueString()
+		settings.endpoint = config.Endpoint.ValueString()
 	}
 
 	if !config.Context.IsNull() {
-		context = config.Context.ValueString()
+		settings.context = config.Context.ValueString()
 	}
 
 	if !config.Username.IsNull() {
-		username = config.Username.ValueString()
+		settings.username = config.Username.ValueString()
 	}
 
 	if !config.Password.IsNull() {
-		password = config.Password.ValueString()
+		settings.password = config.Password.ValueString()
+	}
+
+	if !config.TOTPSecret.IsNull() {
+		settings.totpSecret = config.TOTPSecret.ValueString()
+	}
+
+	if !config.AuthMode.IsNull() {
+		settings.authMode = config.AuthMode.ValueString()
+	}
+
+	if !config.OwnerUser.IsNull() {
+		settings.ownerUser = config.OwnerUser.ValueString()
+	}
+
+	if !config.OwnerContext.IsNull() {
+		settings.ownerContext = config.OwnerContext.ValueString()
+	}
+
+	if !config.MaxRetries.IsNull() {
+		settings.maxRetries = strconv.FormatInt(config.MaxRetries.ValueInt64(), 10)
+	}
+
+	if !config.RetryWaitMin.IsNull() {
+		settings.retryWaitMin = config.RetryWaitMin.ValueString()
+	}
+
+	if !config.RetryWaitMax.IsNull() {
+		settings.retryWaitMax = config.RetryWaitMax.ValueString()
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		settings.maxConcurrentRequests = strconv.FormatInt(config.MaxConcurrentRequests.ValueInt64(), 10)
+	}
+
+	if !config.DisableZoneCache.IsNull() {
+		settings.disableZoneCache = strconv.FormatBool(config.DisableZoneCache.ValueBool())
+	}
+
+	if !config.BatchWindow.IsNull() {
+		settings.batchWindow = config.BatchWindow.ValueString()
 	}
 
 	tflog.Debug(ctx, "creating AutoDNS client")
 
-	if endpoint == "" {
-		endpoint = DEFAULT_API_ENDPOINT
+	client := newClient(&resp.Diagnostics, settings)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if client.AuthMode == api.AuthModeSession {
+		registerSession(client)
 	}
 
-	if context == "" {
-		context = DEFAULT_API_CONTEXT
+	resp.DataSourceData = client
+	resp.ResourceData = client
+
+	ctx = tflog.SetField(ctx, "autodns_endpoint", client.HostURL)
+	ctx = tflog.SetField(ctx, "autodns_context", client.Context)
+	ctx = tflog.SetField(ctx, "autodns_username", client.Username)
+	ctx = tflog.SetField(ctx, "autodns_owner_user", client.Owner.User)
+	tflog.Info(ctx, "configured AutoDNS client successfully")
+}
+
+// clientSettings are the provider settings as strings, like they're read from the environment.
+type clientSettings struct {
+	endpoint              string
+	context               string
+	username              string
+	password              string
+	totpSecret            string
+	authMode              string
+	ownerUser             string
+	ownerContext          string
+	maxRetries            string
+	retryWaitMin          string
+	retryWaitMax          string
+	maxConcurrentRequests string
+	disableZoneCache      string
+	batchWindow           string
+}
+
+// clientSettingsFromEnv reads the provider settings from the AUTODNS_* environment variables.
+func clientSettingsFromEnv() clientSettings {
+	return clientSettings{
+		endpoint:              os.Getenv("AUTODNS_ENDPOINT"),
+		context:               os.Getenv("AUTODNS_CONTEXT"),
+		username:              os.Getenv("AUTODNS_USERNAME"),
+		password:              os.Getenv("AUTODNS_PASSWORD"),
+		totpSecret:            os.Getenv("AUTODNS_TOTP_SECRET"),
+		authMode:              os.Getenv("AUTODNS_AUTH_MODE"),
+		ownerUser:             os.Getenv("AUTODNS_OWNER_USER"),
+		ownerContext:          os.Getenv("AUTODNS_OWNER_CONTEXT"),
+		maxRetries:            os.Getenv("AUTODNS_MAX_RETRIES"),
+		retryWaitMin:          os.Getenv("AUTODNS_RETRY_WAIT_MIN"),
+		retryWaitMax:          os.Getenv("AUTODNS_RETRY_WAIT_MAX"),
+		maxConcurrentRequests: os.Getenv("AUTODNS_MAX_CONCURRENT_REQUESTS"),
+		disableZoneCache:      os.Getenv("AUTODNS_DISABLE_ZONE_CACHE"),
+		batchWindow:           os.Getenv("AUTODNS_BATCH_WINDOW"),
 	}
+}
 
-	if username == "" {
-		resp.Diagnostics.AddAttributeError(
+// newClient validates the settings and creates the API client, defaulting the settings which aren't set.
+// The errors are added to the diagnostics with the path of the matching provider attribute.
+func newClient(diags *diag.Diagnostics, s clientSettings) *api.Client {
+	if s.endpoint == "" {
+		s.endpoint = DEFAULT_API_ENDPOINT
+	}
+
+	if s.context == "" {
+		s.context = DEFAULT_API_CONTEXT
+	}
+
+	if s.username == "" {
+		diags.AddAttributeError(
 			path.Root("username"),
 			"Unknown AutoDNS API Username",
 			"The provider cannot create the AutoDNS API client as there is an unknown configuration value for the AutoDNS API username. "+
@@ -118,8 +290,8 @@ func (p *AutoDNSProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if password == "" {
-		resp.Diagnostics.AddAttributeError(
+	if s.password == "" {
+		diags.AddAttributeError(
 			path.Root("password"),
 			"Unknown AutoDNS API Password",
 			"The provider cannot create the AutoDNS API client as there is an unknown configuration value for the AutoDNS API password. "+
@@ -127,26 +299,195 @@ func (p *AutoDNSProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	if s.totpSecret != "" {
+		if _, err := api.TOTPCode(s.totpSecret, time.Now()); err != nil {
+			diags.AddAttributeError(
+				path.Root("totp_secret"),
+				"Invalid AutoDNS TOTP Secret",
+				fmt.Sprintf("The TOTP secret can't be used to generate one-time codes: %s", err),
+			)
+		}
+	}
+
+	if s.authMode == "" {
+		s.authMode = api.AuthModeBasic
+	}
+
+	if s.authMode != api.AuthModeBasic && s.authMode != api.AuthModeSession {
+		diags.AddAttributeError(
+			path.Root("auth_mode"),
+			"Invalid AutoDNS Auth Mode",
+			fmt.Sprintf("The auth mode must be %q or %q, got: %q", api.AuthModeBasic, api.AuthModeSession, s.authMode),
+		)
+	}
+
+	if _, err := strconv.Atoi(s.context); s.authMode == api.AuthModeSession && err != nil {
+		diags.AddAttributeError(
+			path.Root("context"),
+			"Invalid AutoDNS Context",
+			fmt.Sprintf("The context must be a number to log in with a session, got: %q", s.context),
+		)
+	}
+
+	retries := api.DefaultMaxRetries
+	if s.maxRetries != "" {
+		v, err := strconv.Atoi(s.maxRetries)
+		if err != nil || v < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid AutoDNS Max Retries",
+				fmt.Sprintf("The max retries must be zero or a positive number, got: %q", s.maxRetries),
+			)
+		}
+		retries = v
+	}
+
+	concurrency := api.DefaultMaxConcurrentRequests
+	if s.maxConcurrentRequests != "" {
+		v, err := strconv.Atoi(s.maxConcurrentRequests)
+		if err != nil || v < 1 {
+			diags.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid AutoDNS Max Concurrent Requests",
+				fmt.Sprintf("The max concurrent requests must be at least 1, got: %q", s.maxConcurrentRequests),
+			)
+		}
+		concurrency = v
+	}
+
+	noZoneCache := false
+	if s.disableZoneCache != "" {
+		v, err := strconv.ParseBool(s.disableZoneCache)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("disable_zone_cache"),
+				"Invalid AutoDNS Zone Cache Setting",
+				fmt.Sprintf("The zone cache setting must be a boolean, got: %q", s.disableZoneCache),
+			)
+		}
+		noZoneCache = v
+	}
+
+	waitMin := parseDurationAttribute(diags, path.Root("retry_wait_min"), s.retryWaitMin, api.DefaultRetryWaitMin)
+	waitMax := parseDurationAttribute(diags, path.Root("retry_wait_max"), s.retryWaitMax, api.DefaultRetryWaitMax)
+	window := parseDurationAttribute(diags, path.Root("batch_window"), s.batchWindow, api.DefaultBatchWindow)
+
+	if waitMin > waitMax {
+		diags.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid AutoDNS Retry Wait",
+			fmt.Sprintf("The minimum retry wait must not exceed the maximum retry wait, got: %s > %s", waitMin, waitMax),
+		)
+	}
+
+	if diags.HasError() {
+		return nil
 	}
 
 	// Create our API client
-	client := api.NewClient(endpoint, context, username, password)
+	client := api.NewClient(s.endpoint, s.context, s.username, s.password)
+	client.TOTPSecret = s.totpSecret
+	client.AuthMode = s.authMode
+	client.Owner = api.Owner{User: s.ownerUser, Context: s.ownerContext}
+	client.MaxRetries = retries
+	client.RetryWaitMin = waitMin
+	client.RetryWaitMax = waitMax
+	client.MaxConcurrentRequests = concurrency
+	client.DisableZoneCache = noZoneCache
+	client.BatchWindow = window
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	return client
+}
 
-	ctx = tflog.SetField(ctx, "autodns_endpoint", endpoint)
-	ctx = tflog.SetField(ctx, "autodns_context", context)
-	ctx = tflog.SetField(ctx, "autodns_username", username)
-	tflog.Info(ctx, "configured AutoDNS client successfully")
+// NewClientFromEnv creates the API client like the provider does when it's only configured with environment variables.
+func NewClientFromEnv() (*api.Client, error) {
+	var diags diag.Diagnostics
+
+	client := newClient(&diags, clientSettingsFromEnv())
+	if diags.HasError() {
+		errs := []error{}
+		for _, d := range diags.Errors() {
+			errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	return client, nil
+}
+
+// sessions holds the clients logging in with a session, so they can be logged out on shutdown.
+var sessions struct {
+	mu      sync.Mutex
+	clients []*api.Client
+}
+
+func registerSession(client *api.Client) {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+
+	sessions.clients = append(sessions.clients, client)
+}
+
+// ShutdownTimeout bounds the logout on shutdown. Terraform kills the provider about two seconds after
+// asking it to stop, sessions which aren't logged out by then expire on the AutoDNS side.
+const ShutdownTimeout = time.Second
+
+// Shutdown logs out the sessions of all clients configured by the provider in parallel.
+// It must be called once the provider server has stopped.
+func Shutdown(ctx context.Context) error {
+	sessions.mu.Lock()
+	clients := sessions.clients
+	sessions.clients = nil
+	sessions.mu.Unlock()
+
+	errs := make([]error, len(clients))
+
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.Logout(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// parseDurationAttribute parses a duration provider setting, falling back to the default when it's not set.
+func parseDurationAttribute(diags *diag.Diagnostics, p path.Path, value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			fmt.Sprintf("The value must be a non-negative duration like \"1s\" or \"500ms\", got: %q", value),
+		)
+	}
+
+	return d
 }
 
 // Resources registers our resources with the provider.
 func (p *AutoDNSProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewRecordResource,
+		NewZoneResource,
+		NewZoneRecordsResource,
+		NewZoneDNSSECResource,
+		NewDomainResource,
+		NewContactResource,
+		NewCertificateResource,
+		NewRedirectResource,
+		NewNameServerGroupResource,
+		NewGlueHostResource,
+		NewZoneTransferResource,
 	}
 }
 
@@ -154,6 +495,11 @@ func (p *AutoDNSProvider) Resources(ctx context.Context) []func() resource.Resou
 func (p *AutoDNSProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewZoneDataSource,
+		NewRecordsDataSource,
+		NewZonesDataSource,
+		NewZoneFileDataSource,
+		NewContactDataSource,
+		NewRedirectsDataSource,
 	}
 }
 
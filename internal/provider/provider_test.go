@@ -1,7 +1,10 @@
 package provider
 
 import (
+	"context"
 	"os"
+	"terraform-provider-autodns/internal/api"
+	"terraform-provider-autodns/internal/api/autodnstest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -13,8 +16,109 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"autodns": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccLive runs the acceptance tests against the AutoDNS API instead of the mock server.
+var testAccLive = os.Getenv("TF_AUTODNS_LIVE") != ""
+
+// testAccMockZone is the existing zone the acceptance tests use on the mock server.
+var testAccMockZone = api.Zone{
+	Origin:            "acctest.dev",
+	VirtualNameServer: "a.ns14.net",
+	NameServerGroup:   "ns14.net",
+	NameServers: []api.NameServer{
+		{Name: "a.ns14.net"},
+		{Name: "b.ns14.net"},
+		{Name: "c.ns14.net"},
+		{Name: "d.ns14.net"},
+	},
+}
+
+// testAccMockDomain is the registered domain the acceptance tests use on the mock server.
+var testAccMockDomain = api.Domain{
+	Name: "acctest.dev",
+	NameServers: []api.NameServer{
+		{Name: "a.ns14.net"},
+		{Name: "b.ns14.net"},
+	},
+	OwnerC: &api.ContactRef{ID: 1001},
+	AdminC: &api.ContactRef{ID: 1001},
+	TechC:  &api.ContactRef{ID: 1002},
+	ZoneC:  &api.ContactRef{ID: 1002},
+}
+
+// testAccMockContacts are the contact handles of testAccMockDomain on the mock server.
+var testAccMockContacts = []api.Contact{
+	{
+		ID:           1001,
+		Type:         api.ContactTypeOrg,
+		Alias:        "acctest-owner",
+		Organization: "Acceptance Test GmbH",
+		Address:      []string{"Teststraße 1"},
+		City:         "Berlin",
+		PostalCode:   "10115",
+		Country:      "DE",
+		Email:        "owner@acctest.dev",
+	},
+	{
+		ID:         1002,
+		Type:       api.ContactTypeRole,
+		Alias:      "acctest-hostmaster",
+		FirstName:  "Host",
+		LastName:   "Master",
+		Address:    []string{"Teststraße 1"},
+		City:       "Berlin",
+		PostalCode: "10115",
+		Country:    "DE",
+		Email:      "hostmaster@acctest.dev",
+	},
+}
+
+// testAccEnv returns the environment variable, falling back to the mock server value unless the tests run live.
+func testAccEnv(key, mock string) string {
+	if testAccLive {
+		return os.Getenv(key)
+	}
+
+	return mock
+}
+
+func TestMain(m *testing.M) {
+	if testAccLive {
+		os.Exit(m.Run())
+	}
+
+	// The provider is configured through the environment, so every test talks to the mock server
+	s := autodnstest.NewServer()
+	s.AddZone(testAccMockZone)
+	for _, contact := range testAccMockContacts {
+		s.AddContact(contact)
+	}
+	s.AddDomain(testAccMockDomain)
+
+	env := map[string]string{
+		"AUTODNS_ENDPOINT":       s.URL,
+		"AUTODNS_CONTEXT":        s.Context,
+		"AUTODNS_USERNAME":       s.Username,
+		"AUTODNS_PASSWORD":       s.Password,
+		"AUTODNS_RETRY_WAIT_MIN": "10ms",
+		"AUTODNS_RETRY_WAIT_MAX": "10ms",
+	}
+	for k, v := range env {
+		if err := os.Setenv(k, v); err != nil {
+			panic(err)
+		}
+	}
+
+	code := m.Run()
+	s.Close()
+	os.Exit(code)
+}
+
 // testAccPreCheck runs pre-checks before the tests run.
 func testAccPreCheck(t *testing.T) {
+	if !testAccLive {
+		return
+	}
+
 	if os.Getenv("AUTODNS_USERNAME") == "" || os.Getenv("AUTODNS_PASSWORD") == "" {
 		t.Fatalf("Please make sure that AUTODNS_USERNAME and AUTODNS_PASSWORD environment variables are set.")
 	}
@@ -27,3 +131,38 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatalf("Please make sure that TF_AUTODNS_ZONE_ID environment variable is set for record_resource tests to run.")
 	}
 }
+
+func TestShutdown(t *testing.T) {
+	servers := []*autodnstest.Server{autodnstest.NewServer(), autodnstest.NewServer()}
+
+	for _, s := range servers {
+		defer s.Close()
+
+		c := s.APIClient()
+		c.AuthMode = api.AuthModeSession
+
+		if _, err := c.SearchZones(context.Background(), nil); err != nil {
+			t.Fatalf("SearchZones() error = %v", err)
+		}
+		registerSession(c)
+
+		if s.Sessions() != 1 {
+			t.Fatalf("%d sessions are logged in, want 1", s.Sessions())
+		}
+	}
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	for i, s := range servers {
+		if s.Sessions() != 0 {
+			t.Errorf("server %d has %d sessions left after the shutdown", i, s.Sessions())
+		}
+	}
+
+	// The sessions are only logged out once
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown() error = %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net"
 	"regexp"
 	"slices"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"terraform-provider-autodns/internal/api"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -46,6 +48,41 @@ type RecordResourceModel struct {
 	TTL    types.Int64  `tfsdk:"ttl"`
 	Type   types.String `tfsdk:"type"`
 	Values types.List   `tfsdk:"values"`
+
+	MX    types.List `tfsdk:"mx"`
+	SRV   types.List `tfsdk:"srv"`
+	NAPTR types.List `tfsdk:"naptr"`
+	CAA   types.List `tfsdk:"caa"`
+	TLSA  types.List `tfsdk:"tlsa"`
+	SSHFP types.List `tfsdk:"sshfp"`
+	DS    types.List `tfsdk:"ds"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// valueBlocks returns the typed value blocks of the model by their attribute name.
+func (m *RecordResourceModel) valueBlocks() map[string]*types.List {
+	return map[string]*types.List{
+		"mx":    &m.MX,
+		"srv":   &m.SRV,
+		"naptr": &m.NAPTR,
+		"caa":   &m.CAA,
+		"tlsa":  &m.TLSA,
+		"sshfp": &m.SSHFP,
+		"ds":    &m.DS,
+	}
+}
+
+// usesValueBlock reports whether the record values are set through the typed value block of the record type.
+func (m *RecordResourceModel) usesValueBlock() bool {
+	vt, ok := recordValueTypes[m.Type.ValueString()]
+	if !ok {
+		return false
+	}
+
+	block := m.valueBlocks()[vt.attribute]
+	return !block.IsNull() && len(block.Elements()) > 0
 }
 
 func (r *RecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -93,12 +130,26 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"values": schema.ListAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "Record Value",
-				Required:            true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Record Value. Records with a pref field (MX, SRV, NAPTR) use the format '[pref] [value]'. " +
+					"Either this or the typed value block of the record type must be set.",
+				Optional: true,
 			},
 		},
+		Blocks: recordValueBlocks(),
 	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+// recordValueBlocks returns the schema of the typed value blocks.
+func recordValueBlocks() map[string]schema.Block {
+	blocks := map[string]schema.Block{}
+	for _, vt := range recordValueTypes {
+		blocks[vt.attribute] = vt.block
+	}
+
+	return blocks
 }
 
 func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -130,13 +181,20 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), plan.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate an internal ID for the resource.
 	plan.ID = types.StringValue(plan.ZoneID.ValueString() + "__" + plan.Name.ValueString() + "__" + plan.Type.ValueString())
 
 	// Fetch all records
-	records, err := r.client.GetRecords(ctx, plan.ZoneID.ValueString())
+	records, err := r.client.GetRecords(ctx, zoneID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client error", "Could not fetch the zone dns records: "+err.Error())
+		appendClientError(&resp.Diagnostics, "Could not fetch the zone dns records", err, path.Root("zone_id"), path.Empty())
 		return
 	}
 
@@ -161,9 +219,9 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create the resource.
-	err = r.client.CreateRecords(ctx, plan.ZoneID.ValueString(), records)
+	err = r.client.CreateRecords(ctx, zoneID, records)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create record, got error:\n %s", err))
+		appendClientError(&resp.Diagnostics, "Unable to create record", err, path.Root("zone_id"), path.Root("values"))
 		return
 	}
 
@@ -183,10 +241,17 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get a refreshed list of the records in the zone
-	records, err := r.client.GetRecords(ctx, state.ZoneID.ValueString())
+	records, err := r.client.GetRecords(ctx, zoneID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client error", fmt.Sprintf("Could not fetch the zone dns records:\n %s", err.Error()))
+		appendClientError(&resp.Diagnostics, "Could not fetch the zone dns records", err, path.Root("zone_id"), path.Empty())
 		return
 	}
 
@@ -199,7 +264,8 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	record, diags := flattenRecord(ctx, records)
+	// Keep the representation of the values used by the configuration, imports use the plain values
+	record, diags := flattenRecord(ctx, records, state.usesValueBlock())
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -209,6 +275,9 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.Type = record.Type
 	state.TTL = record.TTL
 	state.Values = record.Values
+	for name, block := range record.valueBlocks() {
+		*state.valueBlocks()[name] = *block
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -224,6 +293,13 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), plan.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get old records from the state
 	oldRecords, diags := expandRecord(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -239,9 +315,9 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// API request to update the records
-	err := r.client.UpdateRecords(ctx, plan.ZoneID.ValueString(), oldRecords, newRecords)
+	err := r.client.UpdateRecords(ctx, zoneID, oldRecords, newRecords)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update record, got error:\n %s", err))
+		appendClientError(&resp.Diagnostics, "Unable to update record", err, path.Root("zone_id"), path.Root("values"))
 		return
 	}
 
@@ -258,6 +334,13 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get the records from the state
 	records, diags := expandRecord(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -266,9 +349,9 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	// API call to delete the records
-	err := r.client.DeleteRecords(ctx, state.ZoneID.ValueString(), records)
+	err := r.client.DeleteRecords(ctx, zoneID, records)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete record, got error: %s", err))
+		appendClientError(&resp.Diagnostics, "Unable to delete record", err, path.Root("zone_id"), path.Empty())
 		return
 	}
 }
@@ -276,7 +359,7 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, "__")
 
-	if len(idParts) != 3 || idParts[0] == "" || idParts[2] == "" {
+	if _, err := api.ParseZoneID(idParts[0]); len(idParts) != 3 || err != nil || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
 			fmt.Sprintf("Expected import identifier with format: ZONEID__NAME__TYPE. Got: %q", req.ID),
@@ -299,8 +382,18 @@ func (r *RecordResource) ValidateConfig(ctx context.Context, req resource.Valida
 		return
 	}
 
-	// Skip when Values is still unknown to terraform
-	if config.Values.IsUnknown() {
+	// Validate the zone ID format when it's already known to terraform
+	if !config.ZoneID.IsUnknown() && !config.ZoneID.IsNull() {
+		parseZoneID(&resp.Diagnostics, path.Root("zone_id"), config.ZoneID)
+	}
+
+	resp.Diagnostics.Append(validateRecordValueBlocks(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip when Values is still unknown or the typed value blocks are used
+	if config.Values.IsUnknown() || config.Values.IsNull() {
 		return
 	}
 
@@ -388,7 +481,92 @@ func hasPrefField(s string) bool {
 	return slices.Contains([]string{"MX", "SRV", "NAPTR"}, s)
 }
 
+// validateRecordValueBlocks checks that the values are set exactly once, with the block matching the record type.
+func validateRecordValueBlocks(ctx context.Context, config RecordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	recordType := config.Type.ValueString()
+	blocksSet := 0
+
+	for name, block := range config.valueBlocks() {
+		if block.IsUnknown() {
+			return diags
+		}
+
+		if block.IsNull() || len(block.Elements()) == 0 {
+			continue
+		}
+		blocksSet++
+
+		vt, ok := recordValueTypes[recordType]
+		if !config.Type.IsUnknown() && (!ok || vt.attribute != name) {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Wrong Attribute Configuration",
+				fmt.Sprintf("The %s block can't be used for records of type %s.", name, recordType),
+			)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		values, d := vt.expand(ctx, *block)
+		diags.Append(d...)
+
+		for i, v := range values {
+			for _, message := range v.validate() {
+				diags.AddAttributeError(path.Root(name).AtListIndex(i), "Wrong Attribute Configuration", message)
+			}
+		}
+	}
+
+	if config.Values.IsUnknown() {
+		return diags
+	}
+
+	if blocksSet > 0 && !config.Values.IsNull() {
+		diags.AddAttributeError(
+			path.Root("values"),
+			"Wrong Attribute Configuration",
+			"The values can't be set together with a typed value block.",
+		)
+	}
+
+	if blocksSet == 0 && config.Values.IsNull() {
+		diags.AddAttributeError(
+			path.Root("values"),
+			"Missing Attribute Configuration",
+			"Either the values or the typed value block of the record type must be set.",
+		)
+	}
+
+	return diags
+}
+
 func expandRecord(ctx context.Context, resource RecordResourceModel) ([]api.Record, diag.Diagnostics) {
+	if resource.usesValueBlock() {
+		vt := recordValueTypes[resource.Type.ValueString()]
+
+		values, diags := vt.expand(ctx, *resource.valueBlocks()[vt.attribute])
+
+		records := []api.Record{}
+		for _, v := range values {
+			value, pref := v.apiValue()
+
+			records = append(records, api.Record{
+				Name:  resource.Name.ValueString(),
+				Type:  resource.Type.ValueString(),
+				TTL:   resource.TTL.ValueInt64(),
+				Value: value,
+				Pref:  pref,
+			})
+		}
+
+		return records, diags
+	}
+
 	values := make([]types.String, 0, len(resource.Values.Elements()))
 	diags := resource.Values.ElementsAs(ctx, &values, false)
 
@@ -402,14 +580,15 @@ func expandRecord(ctx context.Context, resource RecordResourceModel) ([]api.Reco
 		}
 
 		if hasPrefField(record.Type) {
-			valueParts := strings.Fields(record.Value)
-			pref, err := strconv.Atoi(valueParts[0])
+			// Everything after the pref is the value, SRV and NAPTR values contain spaces
+			prefPart, value, _ := strings.Cut(strings.TrimSpace(record.Value), " ")
+			pref, err := strconv.Atoi(prefPart)
 
 			if err != nil {
 				diags.AddError("Client error", fmt.Sprintf("Unable to update record, got error:\n %s", err))
 			}
 
-			record.Value = valueParts[1]
+			record.Value = strings.TrimSpace(value)
 			record.Pref = int32(pref)
 		}
 
@@ -419,9 +598,32 @@ func expandRecord(ctx context.Context, resource RecordResourceModel) ([]api.Reco
 	return records, diags
 }
 
-func flattenRecord(ctx context.Context, records []api.Record) (RecordResourceModel, diag.Diagnostics) {
+func flattenRecord(ctx context.Context, records []api.Record, typed bool) (RecordResourceModel, diag.Diagnostics) {
 	state := RecordResourceModel{}
 
+	var diags diag.Diagnostics
+
+	// Unused value blocks are empty lists, like in the plan
+	for recordType, vt := range recordValueTypes {
+		block := state.valueBlocks()[vt.attribute]
+		*block = types.ListValueMust(types.ObjectType{AttrTypes: vt.attrTypes}, []attr.Value{})
+
+		if typed && recordType == records[0].Type {
+			list, d := vt.flatten(ctx, records)
+			diags.Append(d...)
+			*block = list
+		}
+	}
+
+	state.Name = types.StringValue(records[0].Name)
+	state.Type = types.StringValue(records[0].Type)
+	state.TTL = types.Int64Value(records[0].TTL)
+	state.Values = types.ListNull(types.StringType)
+
+	if typed {
+		return state, diags
+	}
+
 	values := []string{}
 	for _, record := range records {
 		v := record.Value
@@ -432,11 +634,9 @@ func flattenRecord(ctx context.Context, records []api.Record) (RecordResourceMod
 		values = append(values, v)
 	}
 
-	tfValues, diags := types.ListValueFrom(ctx, types.StringType, values)
+	tfValues, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
 
-	state.Name = types.StringValue(records[0].Name)
-	state.Type = types.StringValue(records[0].Type)
-	state.TTL = types.Int64Value(records[0].TTL)
 	state.Values = tfValues
 
 	return state, diags
@@ -1,7 +1,6 @@
 package provider
 
 import (
-	"os"
 	"regexp"
 	"testing"
 
@@ -12,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
-var zoneID = os.Getenv("TF_AUTODNS_ZONE_ID")
+var zoneID = testAccEnv("TF_AUTODNS_ZONE_ID", testAccMockZone.ID().String())
 
 var testDataApexRecord = `
 resource "autodns_record" "test" {
@@ -104,6 +103,64 @@ resource "autodns_record" "test" {
 }
 `
 
+var testDataSRVRecord = `
+resource "autodns_record" "test" {
+  zone_id = "` + zoneID + `"
+
+  name = "_sip._tcp.acctest_srv"
+  ttl  = 60
+  type = "SRV"
+
+  srv {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.example.com"
+  }
+}
+`
+
+var testDataSRVRecordUpdated = `
+resource "autodns_record" "test" {
+  zone_id = "` + zoneID + `"
+
+  name = "_sip._tcp.acctest_srv"
+  ttl  = 60
+  type = "SRV"
+
+  srv {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.example.com"
+  }
+
+  srv {
+    priority = 20
+    weight   = 0
+    port     = 5061
+    target   = "sip2.example.com"
+  }
+}
+`
+
+var testDataSRVBadRecord = `
+resource "autodns_record" "test" {
+  zone_id = "` + zoneID + `"
+
+  name = "_sip._tcp.acctest_srv_bad"
+  ttl  = 60
+  type = "MX"
+
+  srv {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.example.com"
+  }
+}
+`
+
 func TestAccRecordResourceApexRecord(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -418,3 +475,67 @@ func TestAccRecordResourceMXBadRecord(t *testing.T) {
 		},
 	})
 }
+
+func TestAccRecordResourceSRVRecord(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testDataSRVRecord,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_record.test", tfjsonpath.New("id"), knownvalue.StringExact(zoneID+"___sip._tcp.acctest_srv__SRV")),
+					statecheck.ExpectKnownValue("autodns_record.test", tfjsonpath.New("values"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("autodns_record.test", tfjsonpath.New("srv"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"priority": knownvalue.Int64Exact(10),
+							"weight":   knownvalue.Int64Exact(5),
+							"port":     knownvalue.Int64Exact(5060),
+							"target":   knownvalue.StringExact("sip.example.com"),
+						}),
+					})),
+				},
+			},
+			// There should be no changes if we try with the same data
+			{
+				Config: testDataSRVRecord,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Update and Read testing
+			{
+				Config: testDataSRVRecordUpdated,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_record.test", tfjsonpath.New("srv"), knownvalue.ListSizeExact(2)),
+				},
+			},
+			// There should be no changes if we try with the same data
+			{
+				Config: testDataSRVRecordUpdated,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRecordResourceSRVBadRecord(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testDataSRVBadRecord,
+				ExpectError: regexp.MustCompile(".*The srv block can't be used for records of type MX.*"),
+			},
+		},
+	})
+}
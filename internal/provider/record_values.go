@@ -0,0 +1,542 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"terraform-provider-autodns/internal/zonefile"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordValueModel is implemented by the typed value blocks of the record resource.
+type recordValueModel interface {
+	// apiValue serializes the block into the value and pref fields of the API record.
+	apiValue() (string, int32)
+	// validate returns a message for every invalid field of the block.
+	validate() []string
+}
+
+// recordValueType describes the typed value block of a record type.
+type recordValueType struct {
+	attribute string
+	attrTypes map[string]attr.Type
+	block     schema.ListNestedBlock
+	expand    func(ctx context.Context, list types.List) ([]recordValueModel, diag.Diagnostics)
+	flatten   func(ctx context.Context, records []api.Record) (types.List, diag.Diagnostics)
+}
+
+// newRecordValueType wires the typed value block T of a record type.
+func newRecordValueType[T recordValueModel](attribute, description string, attributes map[string]schema.Attribute, attrTypes map[string]attr.Type, parse func(value string, pref int32) (T, error)) recordValueType {
+	return recordValueType{
+		attribute: attribute,
+		attrTypes: attrTypes,
+		block: schema.ListNestedBlock{
+			MarkdownDescription: description,
+			NestedObject: schema.NestedBlockObject{
+				Attributes: attributes,
+			},
+		},
+		expand: func(ctx context.Context, list types.List) ([]recordValueModel, diag.Diagnostics) {
+			values := make([]T, 0, len(list.Elements()))
+			diags := list.ElementsAs(ctx, &values, false)
+
+			models := []recordValueModel{}
+			for _, v := range values {
+				models = append(models, v)
+			}
+
+			return models, diags
+		},
+		flatten: func(ctx context.Context, records []api.Record) (types.List, diag.Diagnostics) {
+			var diags diag.Diagnostics
+
+			values := []T{}
+			for _, r := range records {
+				v, err := parse(r.Value, r.Pref)
+				if err != nil {
+					diags.AddError("Unexpected response", fmt.Sprintf("Unable to parse the %s record value %q: %s", r.Type, r.Value, err))
+					continue
+				}
+				values = append(values, v)
+			}
+
+			list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: attrTypes}, values)
+			diags.Append(d...)
+
+			return list, diags
+		},
+	}
+}
+
+// recordValueTypes maps the record types to their typed value block.
+var recordValueTypes = map[string]recordValueType{
+	"MX": newRecordValueType("mx", "Structured MX record values.", map[string]schema.Attribute{
+		"preference": schema.Int64Attribute{MarkdownDescription: "Preference of the mail exchange.", Required: true},
+		"exchange":   schema.StringAttribute{MarkdownDescription: "Hostname of the mail exchange.", Required: true},
+	}, map[string]attr.Type{
+		"preference": types.Int64Type,
+		"exchange":   types.StringType,
+	}, parseMXRecordValue),
+	"SRV": newRecordValueType("srv", "Structured SRV record values.", map[string]schema.Attribute{
+		"priority": schema.Int64Attribute{MarkdownDescription: "Priority of the target host.", Required: true},
+		"weight":   schema.Int64Attribute{MarkdownDescription: "Relative weight for records with the same priority.", Required: true},
+		"port":     schema.Int64Attribute{MarkdownDescription: "Port of the service on the target host.", Required: true},
+		"target":   schema.StringAttribute{MarkdownDescription: "Hostname of the target host.", Required: true},
+	}, map[string]attr.Type{
+		"priority": types.Int64Type,
+		"weight":   types.Int64Type,
+		"port":     types.Int64Type,
+		"target":   types.StringType,
+	}, parseSRVRecordValue),
+	"NAPTR": newRecordValueType("naptr", "Structured NAPTR record values.", map[string]schema.Attribute{
+		"order":       schema.Int64Attribute{MarkdownDescription: "Order in which the records must be processed.", Required: true},
+		"preference":  schema.Int64Attribute{MarkdownDescription: "Preference for records with the same order.", Required: true},
+		"flags":       schema.StringAttribute{MarkdownDescription: "Flags controlling the rewriting, e.g. `U` or `S`.", Required: true},
+		"service":     schema.StringAttribute{MarkdownDescription: "Service parameters, e.g. `E2U+sip`.", Required: true},
+		"regexp":      schema.StringAttribute{MarkdownDescription: "Substitution expression applied to the original string.", Required: true},
+		"replacement": schema.StringAttribute{MarkdownDescription: "Next domain name to query, `.` when the regexp is used.", Required: true},
+	}, map[string]attr.Type{
+		"order":       types.Int64Type,
+		"preference":  types.Int64Type,
+		"flags":       types.StringType,
+		"service":     types.StringType,
+		"regexp":      types.StringType,
+		"replacement": types.StringType,
+	}, parseNAPTRRecordValue),
+	"CAA": newRecordValueType("caa", "Structured CAA record values.", map[string]schema.Attribute{
+		"flags": schema.Int64Attribute{MarkdownDescription: "CAA flags, 128 marks the property as critical.", Required: true},
+		"tag":   schema.StringAttribute{MarkdownDescription: "Property tag, e.g. `issue`, `issuewild` or `iodef`.", Required: true},
+		"value": schema.StringAttribute{MarkdownDescription: "Property value, e.g. `letsencrypt.org`.", Required: true},
+	}, map[string]attr.Type{
+		"flags": types.Int64Type,
+		"tag":   types.StringType,
+		"value": types.StringType,
+	}, parseCAARecordValue),
+	"TLSA": newRecordValueType("tlsa", "Structured TLSA record values.", map[string]schema.Attribute{
+		"usage":         schema.Int64Attribute{MarkdownDescription: "Certificate usage, from 0 to 3.", Required: true},
+		"selector":      schema.Int64Attribute{MarkdownDescription: "Selector, 0 for the full certificate and 1 for the public key.", Required: true},
+		"matching_type": schema.Int64Attribute{MarkdownDescription: "Matching type, 0 for exact match, 1 for SHA-256 and 2 for SHA-512.", Required: true},
+		"data":          schema.StringAttribute{MarkdownDescription: "Certificate association data as a hex string.", Required: true},
+	}, map[string]attr.Type{
+		"usage":         types.Int64Type,
+		"selector":      types.Int64Type,
+		"matching_type": types.Int64Type,
+		"data":          types.StringType,
+	}, parseTLSARecordValue),
+	"SSHFP": newRecordValueType("sshfp", "Structured SSHFP record values.", map[string]schema.Attribute{
+		"algorithm":        schema.Int64Attribute{MarkdownDescription: "Algorithm of the SSH key, e.g. 4 for Ed25519.", Required: true},
+		"fingerprint_type": schema.Int64Attribute{MarkdownDescription: "Fingerprint type, 1 for SHA-1 and 2 for SHA-256.", Required: true},
+		"fingerprint":      schema.StringAttribute{MarkdownDescription: "Fingerprint of the SSH key as a hex string.", Required: true},
+	}, map[string]attr.Type{
+		"algorithm":        types.Int64Type,
+		"fingerprint_type": types.Int64Type,
+		"fingerprint":      types.StringType,
+	}, parseSSHFPRecordValue),
+	"DS": newRecordValueType("ds", "Structured DS record values.", map[string]schema.Attribute{
+		"key_tag":     schema.Int64Attribute{MarkdownDescription: "Key tag of the referenced DNSKEY.", Required: true},
+		"algorithm":   schema.Int64Attribute{MarkdownDescription: "Algorithm of the referenced DNSKEY.", Required: true},
+		"digest_type": schema.Int64Attribute{MarkdownDescription: "Digest type, e.g. 2 for SHA-256.", Required: true},
+		"digest":      schema.StringAttribute{MarkdownDescription: "Digest of the referenced DNSKEY as a hex string.", Required: true},
+	}, map[string]attr.Type{
+		"key_tag":     types.Int64Type,
+		"algorithm":   types.Int64Type,
+		"digest_type": types.Int64Type,
+		"digest":      types.StringType,
+	}, parseDSRecordValue),
+}
+
+// MXRecordModel describes an mx block of the record resource.
+type MXRecordModel struct {
+	Preference types.Int64  `tfsdk:"preference"`
+	Exchange   types.String `tfsdk:"exchange"`
+}
+
+func (m MXRecordModel) apiValue() (string, int32) {
+	return m.Exchange.ValueString(), int32(m.Preference.ValueInt64())
+}
+
+func (m MXRecordModel) validate() []string {
+	return validateFields(
+		checkRange("preference", m.Preference, 0, 65535),
+		checkNotEmpty("exchange", m.Exchange),
+	)
+}
+
+func parseMXRecordValue(value string, pref int32) (MXRecordModel, error) {
+	return MXRecordModel{
+		Preference: types.Int64Value(int64(pref)),
+		Exchange:   types.StringValue(value),
+	}, nil
+}
+
+// SRVRecordModel describes an srv block of the record resource.
+type SRVRecordModel struct {
+	Priority types.Int64  `tfsdk:"priority"`
+	Weight   types.Int64  `tfsdk:"weight"`
+	Port     types.Int64  `tfsdk:"port"`
+	Target   types.String `tfsdk:"target"`
+}
+
+func (m SRVRecordModel) apiValue() (string, int32) {
+	return fmt.Sprintf("%d %d %s", m.Weight.ValueInt64(), m.Port.ValueInt64(), m.Target.ValueString()), int32(m.Priority.ValueInt64())
+}
+
+func (m SRVRecordModel) validate() []string {
+	return validateFields(
+		checkRange("priority", m.Priority, 0, 65535),
+		checkRange("weight", m.Weight, 0, 65535),
+		checkRange("port", m.Port, 0, 65535),
+		checkNotEmpty("target", m.Target),
+	)
+}
+
+func parseSRVRecordValue(value string, pref int32) (SRVRecordModel, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return SRVRecordModel{}, fmt.Errorf("expected the format: [weight] [port] [target]")
+	}
+
+	numbers, err := parseNumbers(fields[:2])
+	if err != nil {
+		return SRVRecordModel{}, err
+	}
+
+	return SRVRecordModel{
+		Priority: types.Int64Value(int64(pref)),
+		Weight:   types.Int64Value(numbers[0]),
+		Port:     types.Int64Value(numbers[1]),
+		Target:   types.StringValue(fields[2]),
+	}, nil
+}
+
+// NAPTRRecordModel describes a naptr block of the record resource.
+type NAPTRRecordModel struct {
+	Order       types.Int64  `tfsdk:"order"`
+	Preference  types.Int64  `tfsdk:"preference"`
+	Flags       types.String `tfsdk:"flags"`
+	Service     types.String `tfsdk:"service"`
+	Regexp      types.String `tfsdk:"regexp"`
+	Replacement types.String `tfsdk:"replacement"`
+}
+
+func (m NAPTRRecordModel) apiValue() (string, int32) {
+	return fmt.Sprintf("%d %s %s %s %s",
+		m.Preference.ValueInt64(),
+		zonefile.Quote(m.Flags.ValueString()),
+		zonefile.Quote(m.Service.ValueString()),
+		zonefile.Quote(m.Regexp.ValueString()),
+		m.Replacement.ValueString(),
+	), int32(m.Order.ValueInt64())
+}
+
+func (m NAPTRRecordModel) validate() []string {
+	return validateFields(
+		checkRange("order", m.Order, 0, 65535),
+		checkRange("preference", m.Preference, 0, 65535),
+		checkPattern("flags", m.Flags, naptrFlagsPattern, "must only contain letters and digits"),
+		checkNotEmpty("replacement", m.Replacement),
+	)
+}
+
+var naptrFlagsPattern = regexp.MustCompile("^[A-Za-z0-9]*$")
+
+func parseNAPTRRecordValue(value string, pref int32) (NAPTRRecordModel, error) {
+	fields, err := splitQuoted(value)
+	if err != nil {
+		return NAPTRRecordModel{}, err
+	}
+
+	if len(fields) != 5 {
+		return NAPTRRecordModel{}, fmt.Errorf("expected the format: [preference] \"[flags]\" \"[service]\" \"[regexp]\" [replacement]")
+	}
+
+	numbers, err := parseNumbers(fields[:1])
+	if err != nil {
+		return NAPTRRecordModel{}, err
+	}
+
+	return NAPTRRecordModel{
+		Order:       types.Int64Value(int64(pref)),
+		Preference:  types.Int64Value(numbers[0]),
+		Flags:       types.StringValue(fields[1]),
+		Service:     types.StringValue(fields[2]),
+		Regexp:      types.StringValue(fields[3]),
+		Replacement: types.StringValue(fields[4]),
+	}, nil
+}
+
+// CAARecordModel describes a caa block of the record resource.
+type CAARecordModel struct {
+	Flags types.Int64  `tfsdk:"flags"`
+	Tag   types.String `tfsdk:"tag"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (m CAARecordModel) apiValue() (string, int32) {
+	return fmt.Sprintf("%d %s %s", m.Flags.ValueInt64(), m.Tag.ValueString(), zonefile.Quote(m.Value.ValueString())), 0
+}
+
+func (m CAARecordModel) validate() []string {
+	return validateFields(
+		checkRange("flags", m.Flags, 0, 255),
+		checkPattern("tag", m.Tag, caaTagPattern, "must only contain lowercase letters and digits"),
+	)
+}
+
+var caaTagPattern = regexp.MustCompile("^[a-z0-9]+$")
+
+func parseCAARecordValue(value string, pref int32) (CAARecordModel, error) {
+	fields, err := splitQuoted(value)
+	if err != nil {
+		return CAARecordModel{}, err
+	}
+
+	if len(fields) != 3 {
+		return CAARecordModel{}, fmt.Errorf("expected the format: [flags] [tag] \"[value]\"")
+	}
+
+	numbers, err := parseNumbers(fields[:1])
+	if err != nil {
+		return CAARecordModel{}, err
+	}
+
+	return CAARecordModel{
+		Flags: types.Int64Value(numbers[0]),
+		Tag:   types.StringValue(fields[1]),
+		Value: types.StringValue(fields[2]),
+	}, nil
+}
+
+// TLSARecordModel describes a tlsa block of the record resource.
+type TLSARecordModel struct {
+	Usage        types.Int64  `tfsdk:"usage"`
+	Selector     types.Int64  `tfsdk:"selector"`
+	MatchingType types.Int64  `tfsdk:"matching_type"`
+	Data         types.String `tfsdk:"data"`
+}
+
+func (m TLSARecordModel) apiValue() (string, int32) {
+	return fmt.Sprintf("%d %d %d %s", m.Usage.ValueInt64(), m.Selector.ValueInt64(), m.MatchingType.ValueInt64(), m.Data.ValueString()), 0
+}
+
+func (m TLSARecordModel) validate() []string {
+	return validateFields(
+		checkRange("usage", m.Usage, 0, 3),
+		checkRange("selector", m.Selector, 0, 1),
+		checkRange("matching_type", m.MatchingType, 0, 2),
+		checkHex("data", m.Data),
+	)
+}
+
+func parseTLSARecordValue(value string, pref int32) (TLSARecordModel, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return TLSARecordModel{}, fmt.Errorf("expected the format: [usage] [selector] [matching type] [data]")
+	}
+
+	numbers, err := parseNumbers(fields[:3])
+	if err != nil {
+		return TLSARecordModel{}, err
+	}
+
+	return TLSARecordModel{
+		Usage:        types.Int64Value(numbers[0]),
+		Selector:     types.Int64Value(numbers[1]),
+		MatchingType: types.Int64Value(numbers[2]),
+		Data:         types.StringValue(fields[3]),
+	}, nil
+}
+
+// SSHFPRecordModel describes an sshfp block of the record resource.
+type SSHFPRecordModel struct {
+	Algorithm       types.Int64  `tfsdk:"algorithm"`
+	FingerprintType types.Int64  `tfsdk:"fingerprint_type"`
+	Fingerprint     types.String `tfsdk:"fingerprint"`
+}
+
+func (m SSHFPRecordModel) apiValue() (string, int32) {
+	return fmt.Sprintf("%d %d %s", m.Algorithm.ValueInt64(), m.FingerprintType.ValueInt64(), m.Fingerprint.ValueString()), 0
+}
+
+func (m SSHFPRecordModel) validate() []string {
+	return validateFields(
+		checkRange("algorithm", m.Algorithm, 0, 255),
+		checkRange("fingerprint_type", m.FingerprintType, 0, 255),
+		checkHex("fingerprint", m.Fingerprint),
+	)
+}
+
+func parseSSHFPRecordValue(value string, pref int32) (SSHFPRecordModel, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return SSHFPRecordModel{}, fmt.Errorf("expected the format: [algorithm] [fingerprint type] [fingerprint]")
+	}
+
+	numbers, err := parseNumbers(fields[:2])
+	if err != nil {
+		return SSHFPRecordModel{}, err
+	}
+
+	return SSHFPRecordModel{
+		Algorithm:       types.Int64Value(numbers[0]),
+		FingerprintType: types.Int64Value(numbers[1]),
+		Fingerprint:     types.StringValue(fields[2]),
+	}, nil
+}
+
+// DSRecordModel describes a ds block of the record resource.
+type DSRecordModel struct {
+	KeyTag     types.Int64  `tfsdk:"key_tag"`
+	Algorithm  types.Int64  `tfsdk:"algorithm"`
+	DigestType types.Int64  `tfsdk:"digest_type"`
+	Digest     types.String `tfsdk:"digest"`
+}
+
+func (m DSRecordModel) apiValue() (string, int32) {
+	return fmt.Sprintf("%d %d %d %s", m.KeyTag.ValueInt64(), m.Algorithm.ValueInt64(), m.DigestType.ValueInt64(), m.Digest.ValueString()), 0
+}
+
+func (m DSRecordModel) validate() []string {
+	return validateFields(
+		checkRange("key_tag", m.KeyTag, 0, 65535),
+		checkRange("algorithm", m.Algorithm, 0, 255),
+		checkRange("digest_type", m.DigestType, 0, 255),
+		checkHex("digest", m.Digest),
+	)
+}
+
+func parseDSRecordValue(value string, pref int32) (DSRecordModel, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return DSRecordModel{}, fmt.Errorf("expected the format: [key tag] [algorithm] [digest type] [digest]")
+	}
+
+	numbers, err := parseNumbers(fields[:3])
+	if err != nil {
+		return DSRecordModel{}, err
+	}
+
+	return DSRecordModel{
+		KeyTag:     types.Int64Value(numbers[0]),
+		Algorithm:  types.Int64Value(numbers[1]),
+		DigestType: types.Int64Value(numbers[2]),
+		Digest:     types.StringValue(fields[3]),
+	}, nil
+}
+
+// validateFields collects the messages of the failed checks.
+func validateFields(checks ...string) []string {
+	messages := []string{}
+	for _, c := range checks {
+		if c != "" {
+			messages = append(messages, c)
+		}
+	}
+
+	return messages
+}
+
+func checkRange(name string, v types.Int64, minimum, maximum int64) string {
+	if v.IsUnknown() || v.IsNull() {
+		return ""
+	}
+
+	if v.ValueInt64() < minimum || v.ValueInt64() > maximum {
+		return fmt.Sprintf("%s must be between %d and %d, got: %d", name, minimum, maximum, v.ValueInt64())
+	}
+
+	return ""
+}
+
+func checkNotEmpty(name string, v types.String) string {
+	if v.IsUnknown() || v.IsNull() {
+		return ""
+	}
+
+	if strings.TrimSpace(v.ValueString()) == "" {
+		return fmt.Sprintf("%s must not be empty", name)
+	}
+
+	return ""
+}
+
+func checkPattern(name string, v types.String, pattern *regexp.Regexp, message string) string {
+	if v.IsUnknown() || v.IsNull() {
+		return ""
+	}
+
+	if !pattern.MatchString(v.ValueString()) {
+		return fmt.Sprintf("%s %s, got: %q", name, message, v.ValueString())
+	}
+
+	return ""
+}
+
+func checkHex(name string, v types.String) string {
+	if v.IsUnknown() || v.IsNull() {
+		return ""
+	}
+
+	if _, err := hex.DecodeString(v.ValueString()); err != nil || v.ValueString() == "" {
+		return fmt.Sprintf("%s must be a hex string, got: %q", name, v.ValueString())
+	}
+
+	return ""
+}
+
+func parseNumbers(fields []string) ([]int64, error) {
+	numbers := []int64{}
+	for _, f := range fields {
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+
+	return numbers, nil
+}
+
+// splitQuoted splits the value on whitespace, keeping quoted character strings together and unquoting them.
+func splitQuoted(value string) ([]string, error) {
+	fields := []string{}
+
+	for value = strings.TrimSpace(value); value != ""; value = strings.TrimSpace(value) {
+		if value[0] != '"' {
+			field, rest, _ := strings.Cut(value, " ")
+			fields = append(fields, field)
+			value = rest
+			continue
+		}
+
+		// Find the closing quote, skipping escaped characters
+		end := 1
+		for ; end < len(value) && value[end] != '"'; end++ {
+			if value[end] == '\\' {
+				end++
+			}
+		}
+
+		if end >= len(value) {
+			return nil, fmt.Errorf("unterminated quoted string in %q", value)
+		}
+
+		field, err := zonefile.Unquote(value[:end+1])
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+		value = value[end+1:]
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitQuoted(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: `10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`, want: []string{"10", "U", "E2U+sip", "!^.*$!sip:info@example.com!", "."}},
+		{in: `0 issue "letsencrypt.org"`, want: []string{"0", "issue", "letsencrypt.org"}},
+		{in: `0 iodef "mailto:sec \"team\"@example.com"`, want: []string{"0", "iodef", `mailto:sec "team"@example.com`}},
+		{in: `10 "" "" "" foo.example.com.`, want: []string{"10", "", "", "", "foo.example.com."}},
+		{in: `0 iodef "mailto:s\195\188d@example.com"`, want: []string{"0", "iodef", "mailto:süd@example.com"}},
+		{in: `100 "U" "E2U+sip" "!^\\+49(.*)$!\\1!" .`, want: []string{"100", "U", "E2U+sip", `!^\+49(.*)$!\1!`, "."}},
+	}
+
+	for _, tt := range tests {
+		got, err := splitQuoted(tt.in)
+		if err != nil {
+			t.Errorf("splitQuoted(%q) returned error: %s", tt.in, err)
+			continue
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("splitQuoted(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := splitQuoted(`0 issue "letsencrypt.org`); err == nil {
+		t.Errorf("splitQuoted should fail on unterminated quotes")
+	}
+
+	if _, err := splitQuoted(`0 issue "letsencrypt\256org"`); err == nil {
+		t.Errorf("splitQuoted should fail on escapes exceeding a byte")
+	}
+}
+
+func TestRecordValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		value string
+		pref  int32
+		parse func(string, int32) (recordValueModel, error)
+	}{
+		{value: "mail.example.com", pref: 10, parse: asRecordValue(parseMXRecordValue)},
+		{value: "5 5060 sip.example.com", pref: 10, parse: asRecordValue(parseSRVRecordValue)},
+		{value: `10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`, pref: 100, parse: asRecordValue(parseNAPTRRecordValue)},
+		{value: `0 issue "letsencrypt.org"`, parse: asRecordValue(parseCAARecordValue)},
+		{value: `0 iodef "mailto:s\195\188d@example.com"`, parse: asRecordValue(parseCAARecordValue)},
+		{value: `10 "U" "E2U+sip" "!^\\+49(.*)$!sip:\\1@example.com!" .`, pref: 100, parse: asRecordValue(parseNAPTRRecordValue)},
+		{value: "3 1 1 0123456789abcdef", parse: asRecordValue(parseTLSARecordValue)},
+		{value: "4 2 0123456789abcdef", parse: asRecordValue(parseSSHFPRecordValue)},
+		{value: "12345 13 2 0123456789abcdef", parse: asRecordValue(parseDSRecordValue)},
+	}
+
+	for _, tt := range tests {
+		m, err := tt.parse(tt.value, tt.pref)
+		if err != nil {
+			t.Errorf("parsing %q returned error: %s", tt.value, err)
+			continue
+		}
+
+		if messages := m.validate(); len(messages) != 0 {
+			t.Errorf("parsed value %q is invalid: %v", tt.value, messages)
+		}
+
+		value, pref := m.apiValue()
+		if value != tt.value || pref != tt.pref {
+			t.Errorf("apiValue() = %q, %d, want %q, %d", value, pref, tt.value, tt.pref)
+		}
+	}
+}
+
+func asRecordValue[T recordValueModel](parse func(string, int32) (T, error)) func(string, int32) (recordValueModel, error) {
+	return func(value string, pref int32) (recordValueModel, error) {
+		return parse(value, pref)
+	}
+}
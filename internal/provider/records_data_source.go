@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &RecordsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &RecordsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &RecordsDataSource{}
+)
+
+func NewRecordsDataSource() datasource.DataSource {
+	return &RecordsDataSource{}
+}
+
+// RecordsDataSource defines the data source implementation.
+type RecordsDataSource struct {
+	client *api.Client
+}
+
+// RecordsDataSourceModel describes the data source data model.
+type RecordsDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ZoneID    types.String `tfsdk:"zone_id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Value     types.String `tfsdk:"value"`
+	Records   types.List   `tfsdk:"records"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (d *RecordsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_records"
+}
+
+func (d *RecordsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetch the DNS records of an AutoDNS zone, including the ones not managed by terraform. " +
+			"Only the records matching all of the given filters are returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The zone ID the records have been fetched from.",
+				Computed:            true,
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "AutoDNS zone ID. Must be provided in the format zoneOrigin@zoneVirtualNameServer.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return records with this name, use an empty string for the zone apex.",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return records of this type, e.g. `MX`.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return records with a name matching this regular expression.",
+				Optional:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Only return records with this value.",
+				Optional:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching records, sorted by name, type, pref and value.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the DNS record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Record Type",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "Record TTL",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Record Value",
+							Computed:            true,
+						},
+						"pref": schema.Int64Attribute{
+							MarkdownDescription: "Record preference, used by MX, SRV and NAPTR records.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerDataSourceAttributes())
+}
+
+func (d *RecordsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RecordsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config RecordsDataSourceModel
+
+	// Read the data source config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.NameRegex.IsNull() || config.NameRegex.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(config.NameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("Value is not a valid regular expression: %s", err),
+		)
+	}
+}
+
+func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config RecordsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, config.OwnerUser, config.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), config.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	records, err := d.client.GetRecords(ctx, zoneID)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the zone dns records", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+
+	nameRegex, err := regexp.Compile(config.NameRegex.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Wrong Attribute Configuration", err.Error())
+		return
+	}
+
+	// Drop the records not matching the filters
+	records = slices.DeleteFunc(records, func(r api.Record) bool {
+		return (!config.Name.IsNull() && r.Name != config.Name.ValueString()) ||
+			(!config.Type.IsNull() && !strings.EqualFold(r.Type, config.Type.ValueString())) ||
+			(!config.Value.IsNull() && r.Value != config.Value.ValueString()) ||
+			!nameRegex.MatchString(r.Name)
+	})
+
+	// The API doesn't guarantee an order, so the result is sorted to keep the plan stable
+	slices.SortFunc(records, func(a, b api.Record) int {
+		return cmp.Or(
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.Type, b.Type),
+			cmp.Compare(a.Pref, b.Pref),
+			strings.Compare(a.Value, b.Value),
+		)
+	})
+
+	values := []ZoneRecordModel{}
+	for _, record := range records {
+		values = append(values, ZoneRecordModel{
+			Name:  types.StringValue(record.Name),
+			Type:  types.StringValue(record.Type),
+			TTL:   types.Int64Value(record.TTL),
+			Value: types.StringValue(record.Value),
+			Pref:  types.Int64Value(int64(record.Pref)),
+		})
+	}
+
+	tfRecords, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: zoneRecordAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Map response body to model
+	config.ID = types.StringValue(zoneID.String())
+	config.Records = tfRecords
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
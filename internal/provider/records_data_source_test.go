@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testDataRecordsDataSource = `
+resource "autodns_record" "test" {
+  zone_id = "` + zoneID + `"
+
+  name   = "acctest_records"
+  ttl    = 60
+  type   = "MX"
+  values = ["20 mx2.example.com", "10 mx1.example.com"]
+}
+
+data "autodns_records" "test" {
+  zone_id = autodns_record.test.zone_id
+  name    = autodns_record.test.name
+  type    = "MX"
+}
+
+data "autodns_records" "regex" {
+  zone_id    = autodns_record.test.zone_id
+  name_regex = "^acctest_rec"
+  value      = "mx2.example.com"
+}
+`
+
+func TestAccRecordsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataRecordsDataSource,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.autodns_records.test", tfjsonpath.New("id"), knownvalue.StringExact(zoneID)),
+					statecheck.ExpectKnownValue("data.autodns_records.test", tfjsonpath.New("records"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("acctest_records"),
+							"type":  knownvalue.StringExact("MX"),
+							"ttl":   knownvalue.Int64Exact(60),
+							"value": knownvalue.StringExact("mx1.example.com"),
+							"pref":  knownvalue.Int64Exact(10),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("acctest_records"),
+							"type":  knownvalue.StringExact("MX"),
+							"ttl":   knownvalue.Int64Exact(60),
+							"value": knownvalue.StringExact("mx2.example.com"),
+							"pref":  knownvalue.Int64Exact(20),
+						}),
+					})),
+					statecheck.ExpectKnownValue("data.autodns_records.regex", tfjsonpath.New("records"), knownvalue.ListSizeExact(1)),
+				},
+			},
+		},
+	})
+}
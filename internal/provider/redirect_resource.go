@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &RedirectResource{}
+	_ resource.ResourceWithConfigure      = &RedirectResource{}
+	_ resource.ResourceWithImportState    = &RedirectResource{}
+	_ resource.ResourceWithValidateConfig = &RedirectResource{}
+)
+
+// redirectModes maps the redirect types of the resource to the modes of the API.
+var redirectModes = map[string]string{
+	"301":   api.RedirectModeHTTPX,
+	"302":   api.RedirectModeHTTP,
+	"frame": api.RedirectModeFrame,
+}
+
+// redirectTypes are the redirect types of the resource, in the order they're documented.
+var redirectTypes = []string{"301", "302", "frame"}
+
+func NewRedirectResource() resource.Resource {
+	return &RedirectResource{}
+}
+
+// RedirectResource defines the resource implementation.
+type RedirectResource struct {
+	client *api.Client
+}
+
+// RedirectResourceModel describes the resource data model.
+type RedirectResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Source       types.String `tfsdk:"source"`
+	Target       types.String `tfsdk:"target"`
+	RedirectType types.String `tfsdk:"redirect_type"`
+	Title        types.String `tfsdk:"title"`
+	ForwardPath  types.Bool   `tfsdk:"forward_path"`
+	ForwardQuery types.Bool   `tfsdk:"forward_query"`
+	Domain       types.String `tfsdk:"domain"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (r *RedirectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redirect"
+}
+
+func (r *RedirectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Redirect the HTTP requests for a domain to a URL, e.g. to forward parked or vanity domains. " +
+			"AutoDNS answers the requests for the source, so its zone must point the domain to the AutoDNS redirect servers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The source of the redirect.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The domain which is redirected, e.g. promo.example.com.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target": schema.StringAttribute{
+				MarkdownDescription: "The http or https URL the requests are redirected to.",
+				Required:            true,
+			},
+			"redirect_type": schema.StringAttribute{
+				MarkdownDescription: "How the requests are redirected, `301` for a permanent and `302` for a temporary redirect, " +
+					"`frame` shows the target in a frame while the source stays in the address bar. Defaults to `301`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("301"),
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Page title of `frame` redirects.",
+				Optional:            true,
+			},
+			"forward_path": schema.BoolAttribute{
+				MarkdownDescription: "Whether the path of the request is appended to the target. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"forward_query": schema.BoolAttribute{
+				MarkdownDescription: "Whether the query of the request is appended to the target. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain the source belongs to.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *RedirectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RedirectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RedirectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	// API Call
+	redirect, err := r.client.CreateRedirect(ctx, expandRedirect(plan))
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to create the redirect", err, path.Empty(), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	flattenRedirect(redirect, &plan)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RedirectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RedirectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	redirect, err := r.client.GetRedirect(ctx, state.ID.ValueString())
+	if api.IsNotFound(err) {
+		// The redirect has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the redirect", err, path.Empty(), path.Empty())
+		return
+	}
+
+	if redirect.Type != api.RedirectTypeDomain {
+		resp.Diagnostics.AddError(
+			"Unsupported Redirect",
+			fmt.Sprintf("The redirect of %s is an %s redirect, only DOMAIN redirects are managed by this resource.", redirect.Source, redirect.Type),
+		)
+		return
+	}
+
+	flattenRedirect(redirect, &state)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RedirectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RedirectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	// API Call
+	redirect, err := r.client.UpdateRedirect(ctx, expandRedirect(plan))
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the redirect", err, path.Empty(), path.Empty())
+		return
+	}
+
+	flattenRedirect(redirect, &plan)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RedirectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RedirectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	// API call to delete the redirect, there's nothing left to do when it's gone
+	err := r.client.DeleteRedirect(ctx, state.ID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to delete the redirect", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *RedirectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" || strings.ContainsAny(req.ID, "/@ ") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: SOURCE_DOMAIN. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+func (r *RedirectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config RedirectResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Source.IsUnknown() && strings.ContainsAny(config.Source.ValueString(), "/@ :") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("The source must be a domain without scheme or path, got: %s", config.Source.ValueString()),
+		)
+	}
+
+	if !config.Target.IsUnknown() {
+		if u, err := url.Parse(config.Target.ValueString()); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("target"),
+				"Wrong Attribute Configuration",
+				fmt.Sprintf("The target must be a http or https URL, got: %s", config.Target.ValueString()),
+			)
+		}
+	}
+
+	if config.RedirectType.IsNull() || config.RedirectType.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(redirectTypes, config.RedirectType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("redirect_type"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("The redirect type must be one of %s, got: %s", strings.Join(redirectTypes, ", "), config.RedirectType.ValueString()),
+		)
+		return
+	}
+
+	if !config.Title.IsNull() && config.RedirectType.ValueString() != "frame" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("title"),
+			"Wrong Attribute Configuration",
+			"Only frame redirects can have a title.",
+		)
+	}
+}
+
+// expandRedirect maps the model to the domain redirect of the API.
+func expandRedirect(model RedirectResourceModel) *api.Redirect {
+	return &api.Redirect{
+		Source:       model.Source.ValueString(),
+		Target:       model.Target.ValueString(),
+		Type:         api.RedirectTypeDomain,
+		Mode:         redirectModes[model.RedirectType.ValueString()],
+		Title:        model.Title.ValueString(),
+		ForwardPath:  model.ForwardPath.ValueBool(),
+		ForwardQuery: model.ForwardQuery.ValueBool(),
+	}
+}
+
+// flattenRedirect maps the domain redirect returned by the API into the model.
+func flattenRedirect(redirect *api.Redirect, model *RedirectResourceModel) {
+	model.ID = types.StringValue(redirect.Source)
+	model.Source = types.StringValue(redirect.Source)
+	model.Target = types.StringValue(redirect.Target)
+	model.RedirectType = redirectType(redirect)
+	model.Title = optionalString(redirect.Title)
+	model.ForwardPath = types.BoolValue(redirect.ForwardPath)
+	model.ForwardQuery = types.BoolValue(redirect.ForwardQuery)
+	model.Domain = types.StringValue(redirect.Domain)
+}
+
+// redirectType returns the redirect type of the resource for the mode of the redirect, null for email redirects.
+func redirectType(redirect *api.Redirect) types.String {
+	for t, mode := range redirectModes {
+		if redirect.Type == api.RedirectTypeDomain && redirect.Mode == mode {
+			return types.StringValue(t)
+		}
+	}
+
+	return types.StringNull()
+}
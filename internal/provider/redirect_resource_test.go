@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testRedirectSource = "promo-" + acctest.RandString(8) + "." + zoneOrigin
+
+func testAccRedirectResourceConfig(redirectType, title string) string {
+	return fmt.Sprintf(`
+resource "autodns_redirect" "test" {
+  source        = %q
+  target        = "https://www.%s/landing"
+  redirect_type = %q
+  title         = %s
+  forward_query = false
+}
+
+data "autodns_redirects" "test" {
+  filter = [
+    { key = "source", value = autodns_redirect.test.source },
+  ]
+}
+`, testRedirectSource, zoneOrigin, redirectType, title)
+}
+
+func TestAccRedirectResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRedirectResourceConfig("301", "null"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_redirect.test", tfjsonpath.New("id"), knownvalue.StringExact(testRedirectSource)),
+					statecheck.ExpectKnownValue("autodns_redirect.test", tfjsonpath.New("forward_path"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("autodns_redirect.test", tfjsonpath.New("domain"), knownvalue.StringExact(zoneOrigin)),
+					statecheck.ExpectKnownValue("data.autodns_redirects.test", tfjsonpath.New("redirects"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"source":        knownvalue.StringExact(testRedirectSource),
+							"type":          knownvalue.StringExact("DOMAIN"),
+							"mode":          knownvalue.StringExact("HTTPX"),
+							"redirect_type": knownvalue.StringExact("301"),
+							"forward_query": knownvalue.Bool(false),
+						}),
+					})),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "autodns_redirect.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRedirectResourceConfig("frame", `"Promo"`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_redirect.test", tfjsonpath.New("title"), knownvalue.StringExact("Promo")),
+					statecheck.ExpectKnownValue("data.autodns_redirects.test", tfjsonpath.New("redirects").AtSliceIndex(0).AtMapKey("mode"), knownvalue.StringExact("FRAME")),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
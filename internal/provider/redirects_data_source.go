@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &RedirectsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &RedirectsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &RedirectsDataSource{}
+)
+
+func NewRedirectsDataSource() datasource.DataSource {
+	return &RedirectsDataSource{}
+}
+
+// RedirectsDataSource defines the data source implementation.
+type RedirectsDataSource struct {
+	client *api.Client
+}
+
+// RedirectsDataSourceModel describes the data source data model.
+type RedirectsDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Filters   types.List   `tfsdk:"filter"`
+	Redirects types.List   `tfsdk:"redirects"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// RedirectSummaryModel describes a redirect returned by the data source.
+type RedirectSummaryModel struct {
+	Source       types.String `tfsdk:"source"`
+	Target       types.String `tfsdk:"target"`
+	Type         types.String `tfsdk:"type"`
+	Mode         types.String `tfsdk:"mode"`
+	RedirectType types.String `tfsdk:"redirect_type"`
+	Title        types.String `tfsdk:"title"`
+	ForwardPath  types.Bool   `tfsdk:"forward_path"`
+	ForwardQuery types.Bool   `tfsdk:"forward_query"`
+	Domain       types.String `tfsdk:"domain"`
+}
+
+var redirectSummaryAttrTypes = map[string]attr.Type{
+	"source":        types.StringType,
+	"target":        types.StringType,
+	"type":          types.StringType,
+	"mode":          types.StringType,
+	"redirect_type": types.StringType,
+	"title":         types.StringType,
+	"forward_path":  types.BoolType,
+	"forward_query": types.BoolType,
+	"domain":        types.StringType,
+}
+
+func (d *RedirectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_redirects"
+}
+
+func (d *RedirectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Search the domain and email redirects in AutoDNS. All redirects are returned when no filter is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID, always `redirects`.",
+				Computed:            true,
+			},
+			"filter": searchFilterAttribute("The redirect property to filter on, e.g. `source`, `target`, `type`, `mode`, `domain`, `created` or `updated`.", "`*.example.com`"),
+			"redirects": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching redirects, sorted by source.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The redirected domain, or email address of `SINGLE` email redirects.",
+							Computed:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "The URL or email address the source is redirected to.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the redirect, `DOMAIN` or `EMAIL`.",
+							Computed:            true,
+						},
+						"mode": schema.StringAttribute{
+							MarkdownDescription: "AutoDNS mode of the redirect, `HTTP`, `HTTPX` or `FRAME` for domain and `CATCHALL` or `SINGLE` for email redirects.",
+							Computed:            true,
+						},
+						"redirect_type": schema.StringAttribute{
+							MarkdownDescription: "The `redirect_type` of domain redirects as used by `autodns_redirect`, null for email redirects.",
+							Computed:            true,
+						},
+						"title": schema.StringAttribute{
+							MarkdownDescription: "Page title of frame redirects.",
+							Computed:            true,
+						},
+						"forward_path": schema.BoolAttribute{
+							MarkdownDescription: "Whether the path of the request is appended to the target.",
+							Computed:            true,
+						},
+						"forward_query": schema.BoolAttribute{
+							MarkdownDescription: "Whether the query of the request is appended to the target.",
+							Computed:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain the source belongs to.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerDataSourceAttributes())
+}
+
+func (d *RedirectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RedirectsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config RedirectsDataSourceModel
+
+	// Read the data source config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSearchFilters(ctx, &resp.Diagnostics, config.Filters)
+}
+
+func (d *RedirectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config RedirectsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, config.OwnerUser, config.OwnerContext)
+
+	filters, diags := expandSearchFilters(ctx, config.Filters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	redirects, err := d.client.SearchRedirects(ctx, filters)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to search redirects", err, path.Empty(), path.Root("filter"))
+		return
+	}
+
+	// The API doesn't guarantee an order, so the result is sorted to keep the plan stable
+	slices.SortFunc(redirects, func(a, b api.Redirect) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	values := []RedirectSummaryModel{}
+	for _, redirect := range redirects {
+		values = append(values, RedirectSummaryModel{
+			Source:       types.StringValue(redirect.Source),
+			Target:       types.StringValue(redirect.Target),
+			Type:         types.StringValue(redirect.Type),
+			Mode:         types.StringValue(redirect.Mode),
+			RedirectType: redirectType(&redirect),
+			Title:        optionalString(redirect.Title),
+			ForwardPath:  types.BoolValue(redirect.ForwardPath),
+			ForwardQuery: types.BoolValue(redirect.ForwardQuery),
+			Domain:       types.StringValue(redirect.Domain),
+		})
+	}
+
+	tfRedirects, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: redirectSummaryAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Map response body to model
+	config.ID = types.StringValue("redirects")
+	config.Redirects = tfRedirects
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
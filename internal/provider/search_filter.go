@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// searchFilterOperators are the operators AutoDNS supports in search filters.
+var searchFilterOperators = []string{"EQUAL", "NOT_EQUAL", "LIKE", "NOT_LIKE", "GREATER", "GREATER_EQUAL", "LESS", "LESS_EQUAL"}
+
+// SearchFilterModel describes a search filter of the data sources.
+type SearchFilterModel struct {
+	Key      types.String `tfsdk:"key"`
+	Value    types.String `tfsdk:"value"`
+	Operator types.String `tfsdk:"operator"`
+	Link     types.String `tfsdk:"link"`
+	Filters  types.List   `tfsdk:"filter"`
+}
+
+// SearchNestedFilterModel describes a filter nested in a filter group.
+type SearchNestedFilterModel struct {
+	Key      types.String `tfsdk:"key"`
+	Value    types.String `tfsdk:"value"`
+	Operator types.String `tfsdk:"operator"`
+	Link     types.String `tfsdk:"link"`
+}
+
+// searchFilterAttribute returns the schema of the search filters, with the description of the keys and a LIKE example.
+func searchFilterAttribute(keyDescription, likeExample string) schema.ListNestedAttribute {
+	filterAttributes := map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			MarkdownDescription: keyDescription,
+			Optional:            true,
+		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "The value to compare the property with. `LIKE` filters use `*` as wildcard, e.g. " + likeExample + ".",
+			Optional:            true,
+		},
+		"operator": schema.StringAttribute{
+			MarkdownDescription: "The comparison, one of `EQUAL`, `NOT_EQUAL`, `LIKE`, `NOT_LIKE`, `GREATER`, `GREATER_EQUAL`, `LESS` or `LESS_EQUAL`. Defaults to `EQUAL`.",
+			Optional:            true,
+		},
+		"link": schema.StringAttribute{
+			MarkdownDescription: "How the filter is combined with the following one, `AND` or `OR`. Defaults to `AND`.",
+			Optional:            true,
+		},
+	}
+
+	groupAttributes := maps.Clone(filterAttributes)
+	groupAttributes["filter"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Filters grouped together, the filter must not have a key then. Used to combine filters with `OR` inside of an `AND`.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: filterAttributes,
+		},
+	}
+
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "The search filters, evaluated in order.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: groupAttributes,
+		},
+	}
+}
+
+// expandSearchFilters maps the filters of the model to the API filters.
+func expandSearchFilters(ctx context.Context, list types.List) ([]api.SearchFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := []SearchFilterModel{}
+	diags.Append(list.ElementsAs(ctx, &models, true)...)
+
+	filters := []api.SearchFilter{}
+	for _, m := range models {
+		nestedModels := []SearchNestedFilterModel{}
+		diags.Append(m.Filters.ElementsAs(ctx, &nestedModels, true)...)
+
+		f := expandSearchFilter(SearchNestedFilterModel{Key: m.Key, Value: m.Value, Operator: m.Operator, Link: m.Link})
+		for _, nested := range nestedModels {
+			f.Filters = append(f.Filters, expandSearchFilter(nested))
+		}
+
+		filters = append(filters, f)
+	}
+
+	return filters, diags
+}
+
+func expandSearchFilter(m SearchNestedFilterModel) api.SearchFilter {
+	f := api.SearchFilter{
+		Key:      m.Key.ValueString(),
+		Value:    m.Value.ValueString(),
+		Operator: m.Operator.ValueString(),
+		Link:     m.Link.ValueString(),
+	}
+
+	if f.Key != "" && f.Operator == "" {
+		f.Operator = "EQUAL"
+	}
+
+	return f
+}
+
+// validateSearchFilters adds attribute errors for the filters of the list AutoDNS would reject.
+// Filters with values still unknown to terraform are skipped, they're validated once they're known.
+func validateSearchFilters(ctx context.Context, diags *diag.Diagnostics, list types.List) {
+	for i, v := range list.Elements() {
+		m, ok := decodeSearchFilter[SearchFilterModel](ctx, diags, v)
+		if !ok || searchFilterUnknown(m.Key, m.Operator, m.Link) || m.Filters.IsUnknown() {
+			continue
+		}
+
+		p := path.Root("filter").AtListIndex(i)
+
+		f := expandSearchFilter(SearchNestedFilterModel{Key: m.Key, Value: m.Value, Operator: m.Operator, Link: m.Link})
+		f.Filters = make([]api.SearchFilter, len(m.Filters.Elements()))
+		validateSearchFilter(diags, p, f, true)
+
+		for j, v := range m.Filters.Elements() {
+			n, ok := decodeSearchFilter[SearchNestedFilterModel](ctx, diags, v)
+			if !ok || searchFilterUnknown(n.Key, n.Operator, n.Link) {
+				continue
+			}
+
+			validateSearchFilter(diags, p.AtName("filter").AtListIndex(j), expandSearchFilter(n), false)
+		}
+	}
+}
+
+// decodeSearchFilter decodes the filter of a list element, ok is false when the filter is unknown as a whole.
+func decodeSearchFilter[T any](ctx context.Context, diags *diag.Diagnostics, v attr.Value) (m T, ok bool) {
+	obj, ok := v.(types.Object)
+	if !ok || obj.IsUnknown() || obj.IsNull() {
+		return m, false
+	}
+
+	d := obj.As(ctx, &m, basetypes.ObjectAsOptions{})
+	diags.Append(d...)
+
+	return m, !d.HasError()
+}
+
+// searchFilterUnknown reports whether one of the values of the filter is still unknown to terraform.
+func searchFilterUnknown(values ...types.String) bool {
+	return slices.ContainsFunc(values, types.String.IsUnknown)
+}
+
+// validateSearchFilter adds attribute errors for filters AutoDNS would reject.
+func validateSearchFilter(diags *diag.Diagnostics, p path.Path, f api.SearchFilter, group bool) {
+	switch {
+	case group && f.Key == "" && len(f.Filters) == 0:
+		diags.AddAttributeError(p, "Wrong Attribute Configuration", "Either the key or the nested filters of the filter must be set.")
+	case f.Key != "" && len(f.Filters) != 0:
+		diags.AddAttributeError(p, "Wrong Attribute Configuration", "A filter with a key can't have nested filters.")
+	case !group && f.Key == "":
+		diags.AddAttributeError(p.AtName("key"), "Wrong Attribute Configuration", "The key of a nested filter must be set.")
+	}
+
+	if f.Operator != "" && !slices.Contains(searchFilterOperators, f.Operator) {
+		diags.AddAttributeError(p.AtName("operator"), "Wrong Attribute Configuration", fmt.Sprintf("Unsupported operator: %s", f.Operator))
+	}
+
+	if f.Link != "" && f.Link != "AND" && f.Link != "OR" {
+		diags.AddAttributeError(p.AtName("link"), "Wrong Attribute Configuration", fmt.Sprintf("The link must be AND or OR, got: %s", f.Link))
+	}
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateSearchFilters(t *testing.T) {
+	ctx := context.Background()
+
+	groupType := searchFilterAttribute("", "").NestedObject.Type()
+	nestedType := searchFilterAttribute("", "").NestedObject.Attributes["filter"].GetType().(types.ListType).ElemType
+
+	nestedList := func(filters ...SearchNestedFilterModel) types.List {
+		if len(filters) == 0 {
+			return types.ListNull(nestedType)
+		}
+
+		list, diags := types.ListValueFrom(ctx, nestedType, filters)
+		if diags.HasError() {
+			t.Fatalf("ListValueFrom() diagnostics = %v", diags)
+		}
+
+		return list
+	}
+
+	filter := func(key, operator, link string, nested ...SearchNestedFilterModel) SearchFilterModel {
+		return SearchFilterModel{
+			Key:      optionalString(key),
+			Value:    types.StringValue("example.com"),
+			Operator: optionalString(operator),
+			Link:     optionalString(link),
+			Filters:  nestedList(nested...),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filters []SearchFilterModel
+		want    []path.Path
+	}{
+		{
+			name:    "valid",
+			filters: []SearchFilterModel{filter("origin", "LIKE", "OR"), filter("", "", "", SearchNestedFilterModel{Key: types.StringValue("origin")})},
+		},
+		{
+			name:    "neither key nor nested filters",
+			filters: []SearchFilterModel{filter("", "", "")},
+			want:    []path.Path{path.Root("filter").AtListIndex(0)},
+		},
+		{
+			name:    "key and nested filters",
+			filters: []SearchFilterModel{filter("origin", "", "", SearchNestedFilterModel{Key: types.StringValue("origin")})},
+			want:    []path.Path{path.Root("filter").AtListIndex(0)},
+		},
+		{
+			name:    "nested filter without key",
+			filters: []SearchFilterModel{filter("", "", "", SearchNestedFilterModel{Operator: types.StringValue("EQUAL")})},
+			want:    []path.Path{path.Root("filter").AtListIndex(0).AtName("filter").AtListIndex(0).AtName("key")},
+		},
+		{
+			name: "unknown values",
+			filters: []SearchFilterModel{
+				{Key: types.StringUnknown(), Value: types.StringValue("example.com"), Filters: nestedList()},
+				{Operator: types.StringUnknown(), Filters: nestedList()},
+				{Filters: nestedList(SearchNestedFilterModel{Key: types.StringUnknown()}, SearchNestedFilterModel{Link: types.StringValue("XOR")})},
+				{Filters: types.ListUnknown(nestedType)},
+			},
+			want: []path.Path{path.Root("filter").AtListIndex(2).AtName("filter").AtListIndex(1).AtName("key"), path.Root("filter").AtListIndex(2).AtName("filter").AtListIndex(1).AtName("link")},
+		},
+		{
+			name:    "unsupported operator and link",
+			filters: []SearchFilterModel{filter("origin", "CONTAINS", "XOR")},
+			want:    []path.Path{path.Root("filter").AtListIndex(0).AtName("operator"), path.Root("filter").AtListIndex(0).AtName("link")},
+		},
+	}
+
+	for _, tt := range tests {
+		list, diags := types.ListValueFrom(ctx, groupType, tt.filters)
+		if diags.HasError() {
+			t.Fatalf("ListValueFrom() diagnostics = %v", diags)
+		}
+
+		diags = diag.Diagnostics{}
+		validateSearchFilters(ctx, &diags, list)
+
+		got := []path.Path{}
+		for _, d := range diags.Errors() {
+			got = append(got, d.(diag.DiagnosticWithPath).Path())
+		}
+
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: validateSearchFilters() errors at %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: validateSearchFilters() errors at %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+
+	// Unknown filters are validated once they're known
+	diags := diag.Diagnostics{}
+	validateSearchFilters(ctx, &diags, types.ListUnknown(groupType))
+	if diags.HasError() {
+		t.Errorf("validateSearchFilters() of unknown filters = %v", diags)
+	}
+}
@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"terraform-provider-autodns/internal/api"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -31,6 +33,9 @@ type ZoneDataSourceModel struct {
 	Origin            types.String `tfsdk:"origin"`
 	NameServerGroup   types.String `tfsdk:"name_server_group"`
 	VirtualNameServer types.String `tfsdk:"virtual_name_server"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
 }
 
 func (d *ZoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -57,11 +62,14 @@ func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 			},
 			"virtual_name_server": schema.StringAttribute{
-				MarkdownDescription: "The zone's virtual name server.",
+				MarkdownDescription: "The zone's virtual name server. Must be set to select the zone when the origin exists on more than one virtual name server.",
+				Optional:            true,
 				Computed:            true,
 			},
 		},
 	}
+
+	maps.Copy(resp.Schema.Attributes, ownerDataSourceAttributes())
 }
 
 func (d *ZoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -93,15 +101,17 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	ctx = withOwner(ctx, config.OwnerUser, config.OwnerContext)
+
 	// API Call
-	zone, err := d.client.GetZone(ctx, config.Origin.ValueString())
+	zone, err := d.client.GetZone(ctx, config.Origin.ValueString(), config.VirtualNameServer.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read zone, got error: %s", err))
+		appendClientError(&resp.Diagnostics, "Unable to read zone", err, path.Root("origin"), path.Root("origin"))
 		return
 	}
 
 	// Map response body to model
-	config.ID = types.StringValue(zone.Origin + "@" + zone.VirtualNameServer)
+	config.ID = types.StringValue(zone.ID().String())
 	config.Origin = types.StringValue(zone.Origin)
 	config.NameServerGroup = types.StringValue(zone.NameServerGroup)
 	config.VirtualNameServer = types.StringValue(zone.VirtualNameServer)
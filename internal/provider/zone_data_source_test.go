@@ -1,7 +1,6 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -10,12 +9,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
-var zoneOrigin = os.Getenv("TF_AUTODNS_ZONE_ORIGIN")
+var zoneOrigin = testAccEnv("TF_AUTODNS_ZONE_ORIGIN", testAccMockZone.Origin)
 
 var testAccExampleDataSourceConfig = `
 data "autodns_zone" "test" {
   origin = "` + zoneOrigin + `"
 }
+
+data "autodns_zone" "test_virtual_name_server" {
+  origin              = "` + zoneOrigin + `"
+  virtual_name_server = data.autodns_zone.test.virtual_name_server
+}
 `
 
 func TestAccExampleDataSource(t *testing.T) {
@@ -36,6 +40,7 @@ func TestAccExampleDataSource(t *testing.T) {
 					statecheck.ExpectKnownValue("data.autodns_zone.test", tfjsonpath.New("origin"), knownvalue.StringExact(zoneOrigin)),
 					statecheck.ExpectKnownValue("data.autodns_zone.test", tfjsonpath.New("name_server_group"), knownvalue.StringExact("ns14.net")),
 					statecheck.ExpectKnownValue("data.autodns_zone.test", tfjsonpath.New("virtual_name_server"), knownvalue.StringExact("a.ns14.net")),
+					statecheck.ExpectKnownValue("data.autodns_zone.test_virtual_name_server", tfjsonpath.New("id"), knownvalue.StringExact(zoneID)),
 				},
 			},
 		},
@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &ZoneDNSSECResource{}
+	_ resource.ResourceWithConfigure   = &ZoneDNSSECResource{}
+	_ resource.ResourceWithImportState = &ZoneDNSSECResource{}
+	_ resource.ResourceWithModifyPlan  = &ZoneDNSSECResource{}
+)
+
+func NewZoneDNSSECResource() resource.Resource {
+	return &ZoneDNSSECResource{}
+}
+
+// ZoneDNSSECResource defines the resource implementation.
+type ZoneDNSSECResource struct {
+	client *api.Client
+}
+
+// ZoneDNSSECResourceModel describes the resource data model.
+type ZoneDNSSECResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ZoneID           types.String `tfsdk:"zone_id"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	RolloverTriggers types.Map    `tfsdk:"rollover_triggers"`
+	DNSKeyRecords    types.List   `tfsdk:"dnskey_records"`
+	DSRecords        types.List   `tfsdk:"ds_records"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// DNSKeyRecordModel describes a DNSKEY record of the resource data model.
+type DNSKeyRecordModel struct {
+	KeyTag    types.Int64  `tfsdk:"key_tag"`
+	Flags     types.Int64  `tfsdk:"flags"`
+	Protocol  types.Int64  `tfsdk:"protocol"`
+	Algorithm types.Int64  `tfsdk:"algorithm"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+var dnsKeyRecordAttrTypes = map[string]attr.Type{
+	"key_tag":    types.Int64Type,
+	"flags":      types.Int64Type,
+	"protocol":   types.Int64Type,
+	"algorithm":  types.Int64Type,
+	"public_key": types.StringType,
+}
+
+func (r *ZoneDNSSECResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_dnssec"
+}
+
+func (r *ZoneDNSSECResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage the DNSSEC signing of an AutoDNS zone. The keys are generated by AutoDNS, " +
+			"the DS records can be passed on to the registrar of the domain. Destroying the resource disables the signing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The zone ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "AutoDNS zone ID. Must be provided in the format zoneOrigin@zoneVirtualNameServer.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is signed. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"rollover_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values which replace the keys of the zone with new ones when they change, e.g. a date to roll the keys over regularly.",
+				Optional:            true,
+			},
+			"dnskey_records": schema.ListNestedAttribute{
+				MarkdownDescription: "The DNSKEY records of the zone, empty when the zone isn't signed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							MarkdownDescription: "Key tag of the key.",
+							Computed:            true,
+						},
+						"flags": schema.Int64Attribute{
+							MarkdownDescription: "Flags of the key, 257 for key signing keys and 256 for zone signing keys.",
+							Computed:            true,
+						},
+						"protocol": schema.Int64Attribute{
+							MarkdownDescription: "Protocol of the key, always 3.",
+							Computed:            true,
+						},
+						"algorithm": schema.Int64Attribute{
+							MarkdownDescription: "Algorithm of the key, e.g. 13 for ECDSA P-256 with SHA-256.",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "Base64 encoded public key.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ds_records": schema.ListNestedAttribute{
+				MarkdownDescription: "The SHA-256 DS records of the key signing keys, to be published at the parent zone. Empty when the zone isn't signed. " +
+					"The records have the fields of the `ds` block of `autodns_record`, so they can be used for the delegation of a subdomain.",
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							MarkdownDescription: "Key tag of the key signing key.",
+							Computed:            true,
+						},
+						"algorithm": schema.Int64Attribute{
+							MarkdownDescription: "Algorithm of the key signing key.",
+							Computed:            true,
+						},
+						"digest_type": schema.Int64Attribute{
+							MarkdownDescription: "Digest type, always 2 for SHA-256.",
+							Computed:            true,
+						},
+						"digest": schema.StringAttribute{
+							MarkdownDescription: "Digest of the key signing key as a hex string.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *ZoneDNSSECResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneDNSSECResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ZoneDNSSECResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The keys change when the signing is toggled or the keys are rolled over
+	if !plan.Enabled.Equal(state.Enabled) || !plan.RolloverTriggers.Equal(state.RolloverTriggers) {
+		plan.DNSKeyRecords = types.ListUnknown(types.ObjectType{AttrTypes: dnsKeyRecordAttrTypes})
+		plan.DSRecords = types.ListUnknown(types.ObjectType{AttrTypes: recordValueTypes["DS"].attrTypes})
+
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
+func (r *ZoneDNSSECResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ZoneDNSSECResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), plan.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Enable or disable the signing, the keys are generated by AutoDNS
+	zone, err := r.client.SetZoneDNSSEC(ctx, zoneID, plan.Enabled.ValueBool())
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to configure the DNSSEC signing of the zone", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenZoneDNSSEC(ctx, zone, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneDNSSECResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ZoneDNSSECResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneByID(ctx, zoneID)
+	if api.IsNotFound(err) {
+		// The zone has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the zone", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenZoneDNSSEC(ctx, zone, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ZoneDNSSECResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ZoneDNSSECResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), plan.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zone *api.Zone
+	var err error
+
+	switch {
+	case !plan.Enabled.Equal(state.Enabled):
+		// Toggling the signing generates new keys anyway
+		zone, err = r.client.SetZoneDNSSEC(ctx, zoneID, plan.Enabled.ValueBool())
+	case plan.Enabled.ValueBool() && !plan.RolloverTriggers.Equal(state.RolloverTriggers):
+		zone, err = r.client.RolloverDNSSECKeys(ctx, zoneID)
+	default:
+		zone, err = r.client.GetZoneByID(ctx, zoneID)
+	}
+
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the DNSSEC signing of the zone", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenZoneDNSSEC(ctx, zone, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneDNSSECResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ZoneDNSSECResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.Enabled.ValueBool() {
+		return
+	}
+
+	// API call to disable the signing, there's nothing left to do when the zone is gone
+	_, err := r.client.SetZoneDNSSEC(ctx, zoneID, false)
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to disable the DNSSEC signing of the zone", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *ZoneDNSSECResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := api.ParseZoneID(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: ORIGIN@VIRTUALNAMESERVER. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+}
+
+// flattenZoneDNSSEC maps the signing state and the keys of the zone into the model.
+func flattenZoneDNSSEC(ctx context.Context, zone *api.Zone, model *ZoneDNSSECResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(zone.ID().String())
+	model.ZoneID = types.StringValue(zone.ID().String())
+	model.Enabled = types.BoolValue(zone.DNSSEC)
+
+	keys := []DNSKeyRecordModel{}
+	dsRecords := []DSRecordModel{}
+
+	for _, key := range zone.DNSSECKeys {
+		keyTag, err := key.KeyTag()
+		if err != nil {
+			diags.AddError("Invalid DNSSEC Key", fmt.Sprintf("AutoDNS returned an invalid key for the zone %s: %s", zone.ID(), err))
+			return diags
+		}
+
+		keys = append(keys, DNSKeyRecordModel{
+			KeyTag:    types.Int64Value(int64(keyTag)),
+			Flags:     types.Int64Value(int64(key.Flags)),
+			Protocol:  types.Int64Value(int64(key.Protocol)),
+			Algorithm: types.Int64Value(int64(key.Algorithm)),
+			PublicKey: types.StringValue(key.PublicKey),
+		})
+
+		// Only the key signing keys are referenced by the parent zone
+		if key.Flags != api.DNSKeyFlagsKSK {
+			continue
+		}
+
+		ds, err := key.DS(zone.Origin)
+		if err != nil {
+			diags.AddError("Invalid DNSSEC Key", fmt.Sprintf("AutoDNS returned an invalid key for the zone %s: %s", zone.ID(), err))
+			return diags
+		}
+
+		dsRecords = append(dsRecords, DSRecordModel{
+			KeyTag:     types.Int64Value(int64(ds.KeyTag)),
+			Algorithm:  types.Int64Value(int64(ds.Algorithm)),
+			DigestType: types.Int64Value(int64(ds.DigestType)),
+			Digest:     types.StringValue(ds.Digest),
+		})
+	}
+
+	tfKeys, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dnsKeyRecordAttrTypes}, keys)
+	diags.Append(d...)
+	model.DNSKeyRecords = tfKeys
+
+	tfDSRecords, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: recordValueTypes["DS"].attrTypes}, dsRecords)
+	diags.Append(d...)
+	model.DSRecords = tfDSRecords
+
+	return diags
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func testAccZoneDNSSECResourceConfig(enabled bool, rotation string) string {
+	return fmt.Sprintf(`
+resource "autodns_zone_dnssec" "test" {
+  zone_id = %q
+  enabled = %t
+
+  rollover_triggers = {
+    rotation = %q
+  }
+}
+`, zoneID, enabled, rotation)
+}
+
+func TestAccZoneDNSSECResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccZoneDNSSECResourceConfig(true, "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("id"), knownvalue.StringExact(zoneID)),
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("enabled"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("dnskey_records"), knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("ds_records"), knownvalue.ListSizeExact(1)),
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("ds_records").AtSliceIndex(0).AtMapKey("digest_type"), knownvalue.Int64Exact(2)),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "autodns_zone_dnssec.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rollover_triggers"},
+			},
+			// Key rollover testing
+			{
+				Config: testAccZoneDNSSECResourceConfig(true, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("autodns_zone_dnssec.test", tfjsonpath.New("ds_records")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("ds_records"), knownvalue.ListSizeExact(1)),
+				},
+			},
+			// Disable testing
+			{
+				Config: testAccZoneDNSSECResourceConfig(false, "2"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("enabled"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("autodns_zone_dnssec.test", tfjsonpath.New("ds_records"), knownvalue.ListSizeExact(0)),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"terraform-provider-autodns/internal/api"
+	"terraform-provider-autodns/internal/zonefile"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &ZoneFileDataSource{}
+	_ datasource.DataSourceWithConfigure = &ZoneFileDataSource{}
+)
+
+func NewZoneFileDataSource() datasource.DataSource {
+	return &ZoneFileDataSource{}
+}
+
+// ZoneFileDataSource defines the data source implementation.
+type ZoneFileDataSource struct {
+	client *api.Client
+}
+
+// ZoneFileDataSourceModel describes the data source data model.
+type ZoneFileDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	ZoneID  types.String `tfsdk:"zone_id"`
+	Content types.String `tfsdk:"content"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (d *ZoneFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_file"
+}
+
+func (d *ZoneFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Export an AutoDNS zone as an RFC 1035 zone file, e.g. to feed secondary nameservers or audit tooling.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The zone ID the zone file has been rendered from.",
+				Computed:            true,
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "AutoDNS zone ID. Must be provided in the format zoneOrigin@zoneVirtualNameServer.",
+				Required:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The zone file with the SOA record, the nameservers and all records of the zone. " +
+					"Names are relative to the `$ORIGIN` and the records are sorted by name, type, pref and value. " +
+					"The SOA serial is derived from the time the zone has been updated, as AutoDNS doesn't expose it.",
+				Computed: true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerDataSourceAttributes())
+}
+
+func (d *ZoneFileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ZoneFileDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, config.OwnerUser, config.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), config.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	zone, err := d.client.GetZoneByID(ctx, zoneID)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to read zone", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+
+	// Map response body to model
+	config.ID = types.StringValue(zoneID.String())
+	config.Content = types.StringValue(zonefile.Format(zone))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
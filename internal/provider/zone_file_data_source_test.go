@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+var testDataZoneFileDataSource = `
+resource "autodns_record" "test" {
+  zone_id = "` + zoneID + `"
+
+  name   = "acctest_zone_file"
+  ttl    = 60
+  type   = "TXT"
+  values = ["zone file test"]
+}
+
+data "autodns_zone_file" "test" {
+  zone_id = autodns_record.test.zone_id
+}
+`
+
+func TestAccZoneFileDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataZoneFileDataSource,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.autodns_zone_file.test", "id", zoneID),
+					resource.TestMatchResourceAttr("data.autodns_zone_file.test", "content", regexp.MustCompile(`(?m)^\$ORIGIN `+regexp.QuoteMeta(zoneOrigin)+`\.$`)),
+					resource.TestMatchResourceAttr("data.autodns_zone_file.test", "content", regexp.MustCompile(`(?m)^acctest_zone_file\t60\tIN\tTXT\t"zone file test"$`)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,19 @@
+package provider
+
+import (
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseZoneID parses a zone ID attribute, adding an attribute error when it's malformed.
+func parseZoneID(diags *diag.Diagnostics, p path.Path, value types.String) api.ZoneID {
+	zoneID, err := api.ParseZoneID(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid Zone ID", err.Error())
+	}
+
+	return zoneID
+}
@@ -0,0 +1,504 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ZoneRecordsResource{}
+	_ resource.ResourceWithConfigure      = &ZoneRecordsResource{}
+	_ resource.ResourceWithImportState    = &ZoneRecordsResource{}
+	_ resource.ResourceWithValidateConfig = &ZoneRecordsResource{}
+)
+
+func NewZoneRecordsResource() resource.Resource {
+	return &ZoneRecordsResource{}
+}
+
+// ZoneRecordsResource defines the resource implementation.
+type ZoneRecordsResource struct {
+	client *api.Client
+}
+
+// ZoneRecordsResourceModel describes the resource data model.
+type ZoneRecordsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ZoneID       types.String `tfsdk:"zone_id"`
+	Records      types.Set    `tfsdk:"records"`
+	ExcludeTypes types.Set    `tfsdk:"exclude_types"`
+	ExcludeNames types.List   `tfsdk:"exclude_names"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// ZoneRecordModel describes a single record of the zone records data model.
+type ZoneRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+	Value types.String `tfsdk:"value"`
+	Pref  types.Int64  `tfsdk:"pref"`
+}
+
+var zoneRecordAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"ttl":   types.Int64Type,
+	"value": types.StringType,
+	"pref":  types.Int64Type,
+}
+
+func (r *ZoneRecordsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_records"
+}
+
+func (r *ZoneRecordsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manage all the DNS records of an AutoDNS zone. " +
+			"Records in the zone that are not part of the configuration are removed, unless they are excluded. " +
+			"Destroying this resource removes every record that is not excluded from the zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The zone ID this resource manages the records of.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "AutoDNS zone ID. Must be provided in the format zoneOrigin@zoneVirtualNameServer.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				MarkdownDescription: "The complete set of records the zone must contain. Records excluded by `exclude_types` or `exclude_names` can't be part of it.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the DNS record.",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Record Type",
+							Required:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "Record TTL",
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(60),
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Record Value",
+							Required:            true,
+						},
+						"pref": schema.Int64Attribute{
+							MarkdownDescription: "Record preference, used by MX, SRV and NAPTR records.",
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(0),
+						},
+					},
+				},
+			},
+			"exclude_types": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Record types which are not managed by this resource, e.g. `[\"NS\", \"SOA\"]`.",
+				Optional:            true,
+			},
+			"exclude_names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Regular expressions matching record names which are not managed by this resource.",
+				Optional:            true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *ZoneRecordsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ZoneRecordsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	plan.ID = plan.ZoneID
+
+	resp.Diagnostics.Append(r.apply(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ZoneRecordsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	records, diags := r.ownedRecords(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Records, diags = flattenZoneRecords(ctx, records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ZoneRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ZoneRecordsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	resp.Diagnostics.Append(r.apply(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ZoneRecordsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	records, diags := r.ownedRecords(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(records) == 0 {
+		return
+	}
+
+	// API call to delete the records
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRecords(ctx, zoneID, records)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to delete records", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+}
+
+func (r *ZoneRecordsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := api.ParseZoneID(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: ORIGIN@VIRTUALNAMESERVER. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+}
+
+func (r *ZoneRecordsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ZoneRecordsResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.ExcludeNames.IsUnknown() {
+		patterns := make([]types.String, 0, len(config.ExcludeNames.Elements()))
+		resp.Diagnostics.Append(config.ExcludeNames.ElementsAs(ctx, &patterns, false)...)
+
+		for _, p := range patterns {
+			if p.IsUnknown() {
+				continue
+			}
+
+			if _, err := regexp.Compile(p.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("exclude_names"),
+					"Wrong Attribute Configuration",
+					fmt.Sprintf("Value is not a valid regular expression: %s", err),
+				)
+			}
+		}
+	}
+
+	// Validate the zone ID format when it's already known to terraform
+	if !config.ZoneID.IsUnknown() && !config.ZoneID.IsNull() {
+		parseZoneID(&resp.Diagnostics, path.Root("zone_id"), config.ZoneID)
+	}
+
+	// Skip when the records are still unknown to terraform
+	if config.Records.IsUnknown() {
+		return
+	}
+
+	records := make([]ZoneRecordModel, 0, len(config.Records.Elements()))
+	resp.Diagnostics.Append(config.Records.ElementsAs(ctx, &records, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Excluded records are never read back from the zone, so configuring them would never converge
+	excludeTypes := []string{}
+	for _, v := range config.ExcludeTypes.Elements() {
+		if t, ok := v.(types.String); ok && !t.IsUnknown() {
+			excludeTypes = append(excludeTypes, t.ValueString())
+		}
+	}
+
+	excludeNames := []*regexp.Regexp{}
+	for _, v := range config.ExcludeNames.Elements() {
+		if p, ok := v.(types.String); ok && !p.IsUnknown() {
+			if re, err := regexp.Compile(p.ValueString()); err == nil {
+				excludeNames = append(excludeNames, re)
+			}
+		}
+	}
+
+	for _, record := range records {
+		if record.Name.IsUnknown() || record.Type.IsUnknown() {
+			continue
+		}
+
+		if recordExcluded(excludeTypes, excludeNames, record.Name.ValueString(), record.Type.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"Wrong Attribute Configuration",
+				fmt.Sprintf("The %s record %q is excluded by exclude_types or exclude_names, so it can't be managed by the resource.",
+					record.Type.ValueString(), record.Name.ValueString()),
+			)
+		}
+	}
+
+	for _, record := range records {
+		if record.Value.IsUnknown() || record.Type.IsUnknown() {
+			continue
+		}
+
+		recordType := record.Type.ValueString()
+		value := record.Value.ValueString()
+
+		if recordType != "A" && recordType != "AAAA" {
+			continue
+		}
+
+		ip := net.ParseIP(value)
+		if ip == nil || (recordType == "A" && ip.To4() == nil) || (recordType == "AAAA" && ip.To4() != nil) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"Wrong Attribute Configuration",
+				fmt.Sprintf("Value is not a valid %s record address: %s", recordType, value),
+			)
+		}
+	}
+}
+
+// apply makes the zone contain exactly the planned records with a single stream request.
+// The stream is computed from the current records under the zone lock, so concurrent writes to the zone can't make it stale.
+func (r *ZoneRecordsResource) apply(ctx context.Context, plan ZoneRecordsResourceModel) diag.Diagnostics {
+	excluded, diags := r.excludedRecords(ctx, plan)
+	if diags.HasError() {
+		return diags
+	}
+
+	desired, d := expandZoneRecords(ctx, plan.Records)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	zoneID := parseZoneID(&diags, path.Root("zone_id"), plan.ZoneID)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := r.client.ModifyRecords(ctx, zoneID, func(records []api.Record) (api.ZoneStream, error) {
+		zs := api.DiffRecords(slices.DeleteFunc(records, excluded), desired)
+
+		tflog.Debug(ctx, "updating zone records", map[string]interface{}{
+			"adds": len(zs.Adds),
+			"rems": len(zs.Rems),
+		})
+
+		return zs, nil
+	})
+	if err != nil {
+		appendClientError(&diags, "Unable to update records", err, path.Root("zone_id"), path.Root("records"))
+	}
+
+	return diags
+}
+
+// ownedRecords fetches the zone records and drops the ones excluded from the resource.
+func (r *ZoneRecordsResource) ownedRecords(ctx context.Context, model ZoneRecordsResourceModel) ([]api.Record, diag.Diagnostics) {
+	excluded, diags := r.excludedRecords(ctx, model)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	zoneID := parseZoneID(&diags, path.Root("zone_id"), model.ZoneID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	records, err := r.client.GetRecords(ctx, zoneID)
+	if err != nil {
+		appendClientError(&diags, "Could not fetch the zone dns records", err, path.Root("zone_id"), path.Empty())
+		return nil, diags
+	}
+
+	return slices.DeleteFunc(records, excluded), diags
+}
+
+// excludedRecords returns a function reporting whether a record is excluded from the resource by the exclude attributes.
+func (r *ZoneRecordsResource) excludedRecords(ctx context.Context, model ZoneRecordsResourceModel) (func(api.Record) bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	excludeTypes := []string{}
+	diags.Append(model.ExcludeTypes.ElementsAs(ctx, &excludeTypes, true)...)
+
+	patterns := []string{}
+	diags.Append(model.ExcludeNames.ElementsAs(ctx, &patterns, true)...)
+
+	excludeNames := []*regexp.Regexp{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			diags.AddAttributeError(path.Root("exclude_names"), "Wrong Attribute Configuration", err.Error())
+			continue
+		}
+		excludeNames = append(excludeNames, re)
+	}
+
+	return func(r api.Record) bool {
+		return recordExcluded(excludeTypes, excludeNames, r.Name, r.Type)
+	}, diags
+}
+
+// recordExcluded reports whether the record with the name and type isn't managed by the resource.
+func recordExcluded(excludeTypes []string, excludeNames []*regexp.Regexp, name, recordType string) bool {
+	if slices.Contains(excludeTypes, recordType) {
+		return true
+	}
+
+	return slices.ContainsFunc(excludeNames, func(re *regexp.Regexp) bool {
+		return re.MatchString(name)
+	})
+}
+
+func expandZoneRecords(ctx context.Context, set types.Set) ([]api.Record, diag.Diagnostics) {
+	values := make([]ZoneRecordModel, 0, len(set.Elements()))
+	diags := set.ElementsAs(ctx, &values, false)
+
+	records := []api.Record{}
+	for _, v := range values {
+		records = append(records, api.Record{
+			Name:  v.Name.ValueString(),
+			Type:  v.Type.ValueString(),
+			TTL:   v.TTL.ValueInt64(),
+			Value: v.Value.ValueString(),
+			Pref:  int32(v.Pref.ValueInt64()),
+		})
+	}
+
+	return records, diags
+}
+
+func flattenZoneRecords(ctx context.Context, records []api.Record) (types.Set, diag.Diagnostics) {
+	values := []ZoneRecordModel{}
+	for _, record := range records {
+		values = append(values, ZoneRecordModel{
+			Name:  types.StringValue(record.Name),
+			Type:  types.StringValue(record.Type),
+			TTL:   types.Int64Value(record.TTL),
+			Value: types.StringValue(record.Value),
+			Pref:  types.Int64Value(int64(record.Pref)),
+		})
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: zoneRecordAttrTypes}, values)
+}
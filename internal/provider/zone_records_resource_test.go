@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// The records of the zone are managed authoritatively, so the test runs on a dedicated zone.
+var testZoneRecordsOrigin = "acctest-" + acctest.RandString(8) + ".dev"
+
+var testDataZoneRecords = `
+resource "autodns_zone" "test" {
+  origin              = "` + testZoneRecordsOrigin + `"
+  virtual_name_server = "a.ns14.net"
+
+  name_servers = ["a.ns14.net", "b.ns14.net", "c.ns14.net", "d.ns14.net"]
+}
+
+resource "autodns_zone_records" "test" {
+  zone_id = autodns_zone.test.id
+
+  exclude_types = ["NS", "SOA"]
+
+  records = [
+    {
+      name  = "acctest_zone_records"
+      type  = "A"
+      value = "1.1.1.1"
+    },
+    {
+      name  = "acctest_zone_records"
+      type  = "MX"
+      value = "mail.example.com"
+      pref  = 10
+    },
+  ]
+}
+`
+
+var testDataZoneRecordsUpdated = `
+resource "autodns_zone" "test" {
+  origin              = "` + testZoneRecordsOrigin + `"
+  virtual_name_server = "a.ns14.net"
+
+  name_servers = ["a.ns14.net", "b.ns14.net", "c.ns14.net", "d.ns14.net"]
+}
+
+resource "autodns_zone_records" "test" {
+  zone_id = autodns_zone.test.id
+
+  exclude_types = ["NS", "SOA"]
+
+  records = [
+    {
+      name  = "acctest_zone_records"
+      ttl   = 90
+      type  = "A"
+      value = "2.2.2.2"
+    },
+  ]
+}
+`
+
+func TestAccZoneRecordsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testDataZoneRecords,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_records.test", tfjsonpath.New("id"), knownvalue.StringExact(testZoneRecordsOrigin+"@a.ns14.net")),
+					statecheck.ExpectKnownValue("autodns_zone_records.test", tfjsonpath.New("records"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("acctest_zone_records"),
+							"type":  knownvalue.StringExact("A"),
+							"ttl":   knownvalue.Int64Exact(60),
+							"value": knownvalue.StringExact("1.1.1.1"),
+							"pref":  knownvalue.Int64Exact(0),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("acctest_zone_records"),
+							"type":  knownvalue.StringExact("MX"),
+							"ttl":   knownvalue.Int64Exact(60),
+							"value": knownvalue.StringExact("mail.example.com"),
+							"pref":  knownvalue.Int64Exact(10),
+						}),
+					})),
+				},
+			},
+			// There should be no changes if we try with the same data
+			{
+				Config: testDataZoneRecords,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "autodns_zone_records.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclude_types"},
+			},
+			// Update and Read testing
+			{
+				Config: testDataZoneRecordsUpdated,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_records.test", tfjsonpath.New("records"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("acctest_zone_records"),
+							"type":  knownvalue.StringExact("A"),
+							"ttl":   knownvalue.Int64Exact(90),
+							"value": knownvalue.StringExact("2.2.2.2"),
+							"pref":  knownvalue.Int64Exact(0),
+						}),
+					})),
+				},
+			},
+			// There should be no changes if we try with the same data
+			{
+				Config: testDataZoneRecordsUpdated,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+var testDataZoneRecordsExcluded = `
+resource "autodns_zone_records" "test" {
+  zone_id = "` + zoneID + `"
+
+  exclude_types = ["NS", "SOA"]
+  exclude_names = ["^_acme-challenge"]
+
+  records = [
+    {
+      name  = "_acme-challenge"
+      type  = "TXT"
+      value = "token"
+    },
+  ]
+}
+`
+
+func TestAccZoneRecordsResourceExcludedRecord(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testDataZoneRecordsExcluded,
+				ExpectError: regexp.MustCompile(`is excluded by exclude_types or exclude_names`),
+			},
+		},
+	})
+}
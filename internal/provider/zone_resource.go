@@ -0,0 +1,524 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"strconv"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ZoneResource{}
+	_ resource.ResourceWithConfigure      = &ZoneResource{}
+	_ resource.ResourceWithImportState    = &ZoneResource{}
+	_ resource.ResourceWithModifyPlan     = &ZoneResource{}
+	_ resource.ResourceWithValidateConfig = &ZoneResource{}
+)
+
+func NewZoneResource() resource.Resource {
+	return &ZoneResource{}
+}
+
+// ZoneResource defines the resource implementation.
+type ZoneResource struct {
+	client *api.Client
+}
+
+// ZoneResourceModel describes the resource data model.
+type ZoneResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Origin            types.String `tfsdk:"origin"`
+	VirtualNameServer types.String `tfsdk:"virtual_name_server"`
+	NameServers       types.List   `tfsdk:"name_servers"`
+	SOA               types.Object `tfsdk:"soa"`
+	MainIP            types.String `tfsdk:"main_ip"`
+	WWWInclude        types.Bool   `tfsdk:"www_include"`
+	NameServerGroup   types.String `tfsdk:"name_server_group"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// ZoneSOAModel describes the soa block of the zone data model.
+type ZoneSOAModel struct {
+	Refresh types.Int64  `tfsdk:"refresh"`
+	Retry   types.Int64  `tfsdk:"retry"`
+	Expire  types.Int64  `tfsdk:"expire"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	Email   types.String `tfsdk:"email"`
+}
+
+var zoneSOAAttrTypes = map[string]attr.Type{
+	"refresh": types.Int64Type,
+	"retry":   types.Int64Type,
+	"expire":  types.Int64Type,
+	"ttl":     types.Int64Type,
+	"email":   types.StringType,
+}
+
+func (r *ZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage an AutoDNS zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Zone ID. This is generated by the terraform provider due to the lack of IDs in the API response." +
+					"The format of the ID generated by the provider is 'zoneOrigin@zoneVirtualNameServer' and it can be safely used as an input for 'zone_id' " +
+					"when it's required by the other provider resources.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"origin": schema.StringAttribute{
+				MarkdownDescription: "Zone's domain name.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"virtual_name_server": schema.StringAttribute{
+				MarkdownDescription: "The zone's virtual name server.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_servers": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The nameservers of the zone.",
+				Required:            true,
+			},
+			"soa": schema.SingleNestedAttribute{
+				MarkdownDescription: "The zone's SOA settings. AutoDNS defaults are used when omitted.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"refresh": schema.Int64Attribute{
+						MarkdownDescription: "SOA refresh in seconds.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(43200),
+					},
+					"retry": schema.Int64Attribute{
+						MarkdownDescription: "SOA retry in seconds.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(7200),
+					},
+					"expire": schema.Int64Attribute{
+						MarkdownDescription: "SOA expire in seconds.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(1209600),
+					},
+					"ttl": schema.Int64Attribute{
+						MarkdownDescription: "SOA TTL in seconds.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(86400),
+					},
+					"email": schema.StringAttribute{
+						MarkdownDescription: "SOA email address of the zone's administrator.",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"main_ip": schema.StringAttribute{
+				MarkdownDescription: "The main IP address of the zone, used for the apex record.",
+				Optional:            true,
+			},
+			"www_include": schema.BoolAttribute{
+				MarkdownDescription: "Whether the main IP should also be used for the www subdomain.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"name_server_group": schema.StringAttribute{
+				MarkdownDescription: "The nameserver group attached to the zone, e.g. an `autodns_name_server_group`. " +
+					"Defaults to the group AutoDNS assigns for the virtual name server. " +
+					"The zone moves back to that group when a configured group is removed.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config ZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without a configured group the zone keeps the group AutoDNS assigned. When the group has been configured before,
+	// it's reset and AutoDNS assigns the default group of the virtual name server again.
+	if !config.NameServerGroup.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	configured, diags := req.Private.GetKey(ctx, nameServerGroupConfiguredKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || string(configured) != "true" {
+		return
+	}
+
+	plan.NameServerGroup = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// nameServerGroupConfiguredKey is the private state key recording whether the name_server_group of the zone is configured.
+const nameServerGroupConfiguredKey = "name_server_group_configured"
+
+// privateState is the private state of the resource responses, the framework doesn't export its type.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setNameServerGroupConfigured records in the private state whether the group is configured, so removing it can be planned.
+func setNameServerGroupConfigured(ctx context.Context, private privateState, config tfsdk.Config) diag.Diagnostics {
+	var group types.String
+	diags := config.GetAttribute(ctx, path.Root("name_server_group"), &group)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, nameServerGroupConfiguredKey, []byte(strconv.FormatBool(!group.IsNull())))...)
+
+	return diags
+}
+
+func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ZoneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zone := &api.Zone{}
+	resp.Diagnostics.Append(expandZone(ctx, plan, zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the resource.
+	zone, err := r.client.CreateZone(ctx, zone)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to create zone", err, path.Empty(), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(setNameServerGroupConfigured(ctx, resp.Private, req.Config)...)
+	resp.Diagnostics.Append(flattenZone(ctx, zone, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ZoneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("id"), state.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneByID(ctx, zoneID)
+	if api.IsNotFound(err) {
+		// The zone has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the zone", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenZone(ctx, zone, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ZoneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("id"), plan.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API request to update the zone. The update replaces the whole zone, so the plan is applied on top of
+	// the current zone to keep the records, while record writes to the zone wait for the update.
+	var expandDiags diag.Diagnostics
+	zone, err := r.client.ModifyZone(ctx, zoneID, func(zone *api.Zone) error {
+		expandDiags = expandZone(ctx, plan, zone)
+		if expandDiags.HasError() {
+			return errors.New("invalid zone configuration")
+		}
+
+		return nil
+	})
+	resp.Diagnostics.Append(expandDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update zone", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(setNameServerGroupConfigured(ctx, resp.Private, req.Config)...)
+	resp.Diagnostics.Append(flattenZone(ctx, zone, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ZoneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	// API call to delete the zone
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("id"), state.ID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteZone(ctx, zoneID)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to delete zone", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zoneID, err := api.ParseZoneID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: ORIGIN@VIRTUALNAMESERVER. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("origin"), zoneID.Origin)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("virtual_name_server"), zoneID.VirtualNameServer)...)
+}
+
+func (r *ZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ZoneResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the main IP when it's already known to terraform
+	if !config.MainIP.IsNull() && !config.MainIP.IsUnknown() && net.ParseIP(config.MainIP.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("main_ip"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("Value is not an IP address: %s", config.MainIP.ValueString()),
+		)
+	}
+
+	// Skip when the nameservers are still unknown to terraform
+	if config.NameServers.IsUnknown() {
+		return
+	}
+
+	if len(config.NameServers.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_servers"),
+			"Wrong Attribute Configuration",
+			"At least one nameserver must be set for the zone.",
+		)
+	}
+}
+
+// expandZone applies the zone settings from the model on top of the given zone.
+func expandZone(ctx context.Context, model ZoneResourceModel, zone *api.Zone) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	zone.Origin = model.Origin.ValueString()
+	zone.VirtualNameServer = model.VirtualNameServer.ValueString()
+	zone.WWWInclude = model.WWWInclude.ValueBool()
+
+	// An unknown group is left empty, so AutoDNS assigns the default group of the virtual name server
+	zone.NameServerGroup = model.NameServerGroup.ValueString()
+
+	nameServers := make([]string, 0, len(model.NameServers.Elements()))
+	diags.Append(model.NameServers.ElementsAs(ctx, &nameServers, false)...)
+
+	zone.NameServers = []api.NameServer{}
+	for _, ns := range nameServers {
+		zone.NameServers = append(zone.NameServers, api.NameServer{Name: ns})
+	}
+
+	zone.Main = nil
+	if !model.MainIP.IsNull() {
+		zone.Main = &api.MainIP{Address: model.MainIP.ValueString()}
+	}
+
+	// Leave the SOA untouched when it's computed by the API
+	if !model.SOA.IsNull() && !model.SOA.IsUnknown() {
+		var soa ZoneSOAModel
+		diags.Append(model.SOA.As(ctx, &soa, basetypes.ObjectAsOptions{})...)
+
+		zone.SOA = &api.SOA{
+			Refresh: soa.Refresh.ValueInt64(),
+			Retry:   soa.Retry.ValueInt64(),
+			Expire:  soa.Expire.ValueInt64(),
+			TTL:     soa.TTL.ValueInt64(),
+			Email:   soa.Email.ValueString(),
+		}
+	}
+
+	return diags
+}
+
+// flattenZone maps the zone returned by the API into the model.
+func flattenZone(ctx context.Context, zone *api.Zone, model *ZoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(zone.ID().String())
+	model.Origin = types.StringValue(zone.Origin)
+	model.VirtualNameServer = types.StringValue(zone.VirtualNameServer)
+	model.NameServerGroup = types.StringValue(zone.NameServerGroup)
+	model.WWWInclude = types.BoolValue(zone.WWWInclude)
+
+	nameServers := []string{}
+	for _, ns := range zone.NameServers {
+		nameServers = append(nameServers, ns.Name)
+	}
+
+	tfNameServers, d := types.ListValueFrom(ctx, types.StringType, nameServers)
+	diags.Append(d...)
+	model.NameServers = tfNameServers
+
+	model.MainIP = types.StringNull()
+	if zone.Main != nil && zone.Main.Address != "" {
+		model.MainIP = types.StringValue(zone.Main.Address)
+	}
+
+	soa := api.SOA{}
+	if zone.SOA != nil {
+		soa = *zone.SOA
+	}
+
+	tfSOA, d := types.ObjectValueFrom(ctx, zoneSOAAttrTypes, ZoneSOAModel{
+		Refresh: types.Int64Value(soa.Refresh),
+		Retry:   types.Int64Value(soa.Retry),
+		Expire:  types.Int64Value(soa.Expire),
+		TTL:     types.Int64Value(soa.TTL),
+		Email:   types.StringValue(soa.Email),
+	})
+	diags.Append(d...)
+	model.SOA = tfSOA
+
+	return diags
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testZoneOrigin = "acctest-" + acctest.RandString(8) + ".dev"
+
+var testDataZone = `
+resource "autodns_zone" "test" {
+  origin              = "` + testZoneOrigin + `"
+  virtual_name_server = "a.ns14.net"
+
+  name_servers = ["a.ns14.net", "b.ns14.net", "c.ns14.net", "d.ns14.net"]
+  main_ip      = "1.1.1.1"
+}
+`
+
+var testDataZoneUpdated = `
+resource "autodns_zone" "test" {
+  origin              = "` + testZoneOrigin + `"
+  virtual_name_server = "a.ns14.net"
+
+  name_servers = ["a.ns14.net", "b.ns14.net", "c.ns14.net", "d.ns14.net"]
+  main_ip      = "2.2.2.2"
+  www_include  = true
+
+  soa = {
+    refresh = 43200
+    retry   = 7200
+    expire  = 1209600
+    ttl     = 3600
+    email   = "hostmaster@` + testZoneOrigin + `"
+  }
+}
+`
+
+func TestAccZoneResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testDataZone,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("id"), knownvalue.StringExact(testZoneOrigin+"@a.ns14.net")),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("origin"), knownvalue.StringExact(testZoneOrigin)),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("virtual_name_server"), knownvalue.StringExact("a.ns14.net")),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("name_servers"), knownvalue.ListSizeExact(4)),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("main_ip"), knownvalue.StringExact("1.1.1.1")),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("www_include"), knownvalue.Bool(false)),
+				},
+			},
+			// There should be no changes if we try with the same data
+			{
+				Config: testDataZone,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "autodns_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testDataZoneUpdated,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("id"), knownvalue.StringExact(testZoneOrigin+"@a.ns14.net")),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("main_ip"), knownvalue.StringExact("2.2.2.2")),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("www_include"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("soa").AtMapKey("ttl"), knownvalue.Int64Exact(3600)),
+					statecheck.ExpectKnownValue("autodns_zone.test", tfjsonpath.New("soa").AtMapKey("email"), knownvalue.StringExact("hostmaster@"+testZoneOrigin)),
+				},
+			},
+			// There should be no changes if we try with the same data
+			{
+				Config: testDataZoneUpdated,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ZoneTransferResource{}
+	_ resource.ResourceWithConfigure      = &ZoneTransferResource{}
+	_ resource.ResourceWithImportState    = &ZoneTransferResource{}
+	_ resource.ResourceWithValidateConfig = &ZoneTransferResource{}
+)
+
+// zoneTypes are the zone types of the resource, in the order they're documented.
+var zoneTypes = []string{"primary", "secondary"}
+
+func NewZoneTransferResource() resource.Resource {
+	return &ZoneTransferResource{}
+}
+
+// ZoneTransferResource defines the resource implementation.
+type ZoneTransferResource struct {
+	client *api.Client
+}
+
+// ZoneTransferResourceModel describes the resource data model.
+type ZoneTransferResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ZoneID           types.String `tfsdk:"zone_id"`
+	ZoneType         types.String `tfsdk:"zone_type"`
+	PrimaryIPs       types.List   `tfsdk:"primary_ips"`
+	AllowTransferIPs types.List   `tfsdk:"allow_transfer_ips"`
+	NotifyIPs        types.List   `tfsdk:"notify_ips"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+func (r *ZoneTransferResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_transfer"
+}
+
+func (r *ZoneTransferResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage the zone transfer settings of an AutoDNS zone: whether the zone is a secondary of external primary nameservers, " +
+			"which addresses may transfer the zone with AXFR and which are notified about changes. " +
+			"Destroying the resource turns the zone back into a primary zone without transfer settings.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The zone ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "AutoDNS zone ID. Must be provided in the format zoneOrigin@zoneVirtualNameServer.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone_type": schema.StringAttribute{
+				MarkdownDescription: "Either `primary` for zones served from the records in AutoDNS or `secondary` for zones transferred from the `primary_ips`. Defaults to `primary`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("primary"),
+			},
+			"primary_ips": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IP addresses of the primary nameservers a `secondary` zone is transferred from, at least one is required for secondary zones.",
+				Optional:            true,
+			},
+			"allow_transfer_ips": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IP addresses allowed to transfer the zone with AXFR, e.g. of external secondary nameservers.",
+				Optional:            true,
+			},
+			"notify_ips": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IP addresses which receive a NOTIFY when the zone changes.",
+				Optional:            true,
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerResourceAttributes())
+}
+
+func (r *ZoneTransferResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneTransferResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ZoneTransferResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), plan.ZoneID)
+	transfer, diags := expandZoneTransfer(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	zone, err := r.client.SetZoneTransfer(ctx, zoneID, transfer)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to configure the zone transfer", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(flattenZoneTransfer(ctx, zone, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneTransferResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ZoneTransferResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZoneByID(ctx, zoneID)
+	if api.IsNotFound(err) {
+		// The zone has been deleted outside of terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Could not fetch the zone", err, path.Empty(), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenZoneTransfer(ctx, zone, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ZoneTransferResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ZoneTransferResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, plan.OwnerUser, plan.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), plan.ZoneID)
+	transfer, diags := expandZoneTransfer(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	zone, err := r.client.SetZoneTransfer(ctx, zoneID, transfer)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to update the zone transfer", err, path.Root("zone_id"), path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(flattenZoneTransfer(ctx, zone, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneTransferResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ZoneTransferResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, state.OwnerUser, state.OwnerContext)
+
+	zoneID := parseZoneID(&resp.Diagnostics, path.Root("zone_id"), state.ZoneID)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API call to reset the transfer settings, there's nothing left to do when the zone is gone
+	_, err := r.client.SetZoneTransfer(ctx, zoneID, api.ZoneTransfer{Type: api.ZoneTypePrimary})
+	if err != nil && !api.IsNotFound(err) {
+		appendClientError(&resp.Diagnostics, "Unable to reset the zone transfer", err, path.Empty(), path.Empty())
+		return
+	}
+}
+
+func (r *ZoneTransferResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := api.ParseZoneID(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: ORIGIN@VIRTUALNAMESERVER. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+}
+
+func (r *ZoneTransferResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ZoneTransferResourceModel
+
+	// Read the resource config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, list := range map[string]types.List{"primary_ips": config.PrimaryIPs, "allow_transfer_ips": config.AllowTransferIPs, "notify_ips": config.NotifyIPs} {
+		for i, v := range list.Elements() {
+			s, ok := v.(types.String)
+			if !ok || s.IsUnknown() {
+				continue
+			}
+
+			if net.ParseIP(s.ValueString()) == nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name).AtListIndex(i),
+					"Wrong Attribute Configuration",
+					fmt.Sprintf("Value is not a valid IP address: %s", s.ValueString()),
+				)
+			}
+		}
+	}
+
+	// Skip when the type or the primaries are still unknown to terraform
+	if config.ZoneType.IsUnknown() || config.PrimaryIPs.IsUnknown() {
+		return
+	}
+
+	zoneType := config.ZoneType.ValueString()
+	if config.ZoneType.IsNull() {
+		zoneType = "primary"
+	}
+
+	switch {
+	case !slices.Contains(zoneTypes, zoneType):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("zone_type"),
+			"Wrong Attribute Configuration",
+			fmt.Sprintf("The zone type must be one of %s, got: %s", strings.Join(zoneTypes, ", "), zoneType),
+		)
+	case zoneType == "secondary" && len(config.PrimaryIPs.Elements()) == 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("primary_ips"),
+			"Wrong Attribute Configuration",
+			"At least one primary IP address must be set for secondary zones.",
+		)
+	case zoneType == "primary" && len(config.PrimaryIPs.Elements()) != 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("primary_ips"),
+			"Wrong Attribute Configuration",
+			"Primary IP addresses can only be set for secondary zones.",
+		)
+	}
+}
+
+// expandZoneTransfer returns the transfer settings of the model.
+func expandZoneTransfer(ctx context.Context, model ZoneTransferResourceModel) (api.ZoneTransfer, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	transfer := api.ZoneTransfer{Type: strings.ToUpper(model.ZoneType.ValueString())}
+	diags.Append(model.PrimaryIPs.ElementsAs(ctx, &transfer.Primaries, true)...)
+	diags.Append(model.AllowTransferIPs.ElementsAs(ctx, &transfer.Grants, true)...)
+	diags.Append(model.NotifyIPs.ElementsAs(ctx, &transfer.Notifies, true)...)
+
+	return transfer, diags
+}
+
+// flattenZoneTransfer maps the transfer settings of the zone into the model.
+func flattenZoneTransfer(ctx context.Context, zone *api.Zone, model *ZoneTransferResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	zoneType := strings.ToLower(zone.Type)
+	if zoneType == "" {
+		zoneType = "primary"
+	}
+
+	model.ID = types.StringValue(zone.ID().String())
+	model.ZoneID = types.StringValue(zone.ID().String())
+	model.ZoneType = types.StringValue(zoneType)
+	model.PrimaryIPs = optionalList(ctx, &diags, zone.Primaries, model.PrimaryIPs)
+	model.AllowTransferIPs = optionalList(ctx, &diags, zone.Grants, model.AllowTransferIPs)
+	model.NotifyIPs = optionalList(ctx, &diags, zone.Notifies, model.NotifyIPs)
+
+	return diags
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func testAccZoneTransferResourceConfig(zoneType, primaryIPs string) string {
+	return fmt.Sprintf(`
+resource "autodns_zone_transfer" "test" {
+  zone_id = %q
+
+  zone_type          = %q
+  primary_ips        = %s
+  allow_transfer_ips = ["192.0.2.2", "2001:db8::2"]
+  notify_ips         = ["192.0.2.2"]
+}
+`, zoneID, zoneType, primaryIPs)
+}
+
+func TestAccZoneTransferResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccZoneTransferResourceConfig("primary", "null"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_transfer.test", tfjsonpath.New("id"), knownvalue.StringExact(zoneID)),
+					statecheck.ExpectKnownValue("autodns_zone_transfer.test", tfjsonpath.New("primary_ips"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("autodns_zone_transfer.test", tfjsonpath.New("allow_transfer_ips"), knownvalue.ListSizeExact(2)),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "autodns_zone_transfer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccZoneTransferResourceConfig("secondary", `["192.0.2.1"]`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("autodns_zone_transfer.test", tfjsonpath.New("zone_type"), knownvalue.StringExact("secondary")),
+					statecheck.ExpectKnownValue("autodns_zone_transfer.test", tfjsonpath.New("primary_ips"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("192.0.2.1"),
+					})),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &ZonesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &ZonesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &ZonesDataSource{}
+)
+
+func NewZonesDataSource() datasource.DataSource {
+	return &ZonesDataSource{}
+}
+
+// ZonesDataSource defines the data source implementation.
+type ZonesDataSource struct {
+	client *api.Client
+}
+
+// ZonesDataSourceModel describes the data source data model.
+type ZonesDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Filters types.List   `tfsdk:"filter"`
+	Zones   types.List   `tfsdk:"zones"`
+
+	OwnerUser    types.String `tfsdk:"owner_user"`
+	OwnerContext types.String `tfsdk:"owner_context"`
+}
+
+// ZoneSummaryModel describes a zone returned by the data source.
+type ZoneSummaryModel struct {
+	ID                types.String `tfsdk:"id"`
+	Origin            types.String `tfsdk:"origin"`
+	VirtualNameServer types.String `tfsdk:"virtual_name_server"`
+	NameServerGroup   types.String `tfsdk:"name_server_group"`
+	Created           types.String `tfsdk:"created"`
+	Updated           types.String `tfsdk:"updated"`
+}
+
+var zoneSummaryAttrTypes = map[string]attr.Type{
+	"id":                  types.StringType,
+	"origin":              types.StringType,
+	"virtual_name_server": types.StringType,
+	"name_server_group":   types.StringType,
+	"created":             types.StringType,
+	"updated":             types.StringType,
+}
+
+func (d *ZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+func (d *ZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Search the zones in AutoDNS. All zones are returned when no filter is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID, always `zones`.",
+				Computed:            true,
+			},
+			"filter": searchFilterAttribute("The zone property to filter on, e.g. `origin`, `virtualNameServer`, `nameServerGroup`, `created` or `updated`.", "`*.com`"),
+			"zones": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching zones, sorted by origin and virtual name server.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Zone ID in the format 'zoneOrigin@zoneVirtualNameServer'.",
+							Computed:            true,
+						},
+						"origin": schema.StringAttribute{
+							MarkdownDescription: "Zone's domain name.",
+							Computed:            true,
+						},
+						"virtual_name_server": schema.StringAttribute{
+							MarkdownDescription: "The zone's virtual name server.",
+							Computed:            true,
+						},
+						"name_server_group": schema.StringAttribute{
+							MarkdownDescription: "The nameserver group attached to the zone.",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: "When the zone has been created.",
+							Computed:            true,
+						},
+						"updated": schema.StringAttribute{
+							MarkdownDescription: "When the zone has been updated the last time.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	maps.Copy(resp.Schema.Attributes, ownerDataSourceAttributes())
+}
+
+func (d *ZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZonesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config ZonesDataSourceModel
+
+	// Read the data source config
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSearchFilters(ctx, &resp.Diagnostics, config.Filters)
+}
+
+func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ZonesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withOwner(ctx, config.OwnerUser, config.OwnerContext)
+
+	filters, diags := expandSearchFilters(ctx, config.Filters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// API Call
+	zones, err := d.client.SearchZones(ctx, filters)
+	if err != nil {
+		appendClientError(&resp.Diagnostics, "Unable to search zones", err, path.Empty(), path.Root("filter"))
+		return
+	}
+
+	// The API doesn't guarantee an order, so the result is sorted to keep the plan stable
+	slices.SortFunc(zones, func(a, b api.Zone) int {
+		return cmp.Or(
+			strings.Compare(a.Origin, b.Origin),
+			strings.Compare(a.VirtualNameServer, b.VirtualNameServer),
+		)
+	})
+
+	values := []ZoneSummaryModel{}
+	for _, zone := range zones {
+		values = append(values, ZoneSummaryModel{
+			ID:                types.StringValue(zone.ID().String()),
+			Origin:            types.StringValue(zone.Origin),
+			VirtualNameServer: types.StringValue(zone.VirtualNameServer),
+			NameServerGroup:   types.StringValue(zone.NameServerGroup),
+			Created:           types.StringValue(zone.Created),
+			Updated:           types.StringValue(zone.Updated),
+		})
+	}
+
+	tfZones, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: zoneSummaryAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Map response body to model
+	config.ID = types.StringValue("zones")
+	config.Zones = tfZones
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+var testDataZonesDataSource = `
+data "autodns_zones" "test" {
+  filter = [{
+    key   = "origin"
+    value = "` + zoneOrigin + `"
+  }]
+}
+
+data "autodns_zones" "group" {
+  filter = [
+    {
+      key      = "origin"
+      value    = "*` + zoneOrigin + `"
+      operator = "LIKE"
+    },
+    {
+      filter = [
+        {
+          key   = "virtualNameServer"
+          value = "unknown.example.com"
+          link  = "OR"
+        },
+        {
+          key   = "origin"
+          value = "` + zoneOrigin + `"
+        },
+      ]
+    },
+  ]
+}
+`
+
+func TestAccZonesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataZonesDataSource,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.autodns_zones.test", tfjsonpath.New("id"), knownvalue.StringExact("zones")),
+					statecheck.ExpectKnownValue("data.autodns_zones.test", tfjsonpath.New("zones").AtSliceIndex(0).AtMapKey("origin"), knownvalue.StringExact(zoneOrigin)),
+					statecheck.ExpectKnownValue("data.autodns_zones.group", tfjsonpath.New("zones"), knownvalue.ListSizeExact(1)),
+				},
+			},
+		},
+	})
+}
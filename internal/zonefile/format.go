@@ -0,0 +1,194 @@
+package zonefile
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"time"
+)
+
+// DefaultTTL is used for the $TTL of zones without SOA settings.
+const DefaultTTL = 86400
+
+// DefaultMinimum is the negative caching TTL of the SOA record, unless the SOA settings of the zone have their own.
+const DefaultMinimum = 3600
+
+// maxStringLength is the maximum length of a character string, longer TXT values are split into multiple strings.
+const maxStringLength = 255
+
+// Format renders the zone as a zone file, with the SOA record, the nameservers and all records of the zone.
+// Names are relative to the origin, the records are sorted by name, type, preference and value so the output is stable.
+func Format(zone *api.Zone) string {
+	var b strings.Builder
+
+	ttl := int64(DefaultTTL)
+	soa := api.SOA{}
+	if zone.SOA != nil {
+		soa = *zone.SOA
+		if soa.TTL != 0 {
+			ttl = soa.TTL
+		}
+	}
+
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", canonical(zone.Origin))
+	fmt.Fprintf(&b, "$TTL %d\n", ttl)
+
+	primary := zone.VirtualNameServer
+	if len(zone.NameServers) != 0 {
+		primary = zone.NameServers[0].Name
+	}
+
+	email := soa.Email
+	if email == "" {
+		email = "hostmaster@" + canonical(zone.Origin)
+	}
+
+	fmt.Fprintf(&b, "@\t%d\tIN\tSOA\t%s %s %d %d %d %d %d\n",
+		ttl, fqdn(primary), mailbox(email), serial(zone.Updated), soa.Refresh, soa.Retry, soa.Expire, cmp.Or(soa.Minimum, DefaultMinimum))
+
+	records := []api.Record{}
+	for _, ns := range zone.NameServers {
+		records = append(records, api.Record{Type: "NS", TTL: cmp.Or(ns.TTL, ttl), Value: canonical(ns.Name)})
+	}
+
+	// The main IP is a shortcut for the A or AAAA record of the zone apex, and of www when it's included
+	if zone.Main != nil && zone.Main.Address != "" {
+		main := api.Record{Type: "A", TTL: cmp.Or(zone.Main.TTL, ttl), Value: zone.Main.Address}
+		if ip := net.ParseIP(zone.Main.Address); ip != nil && ip.To4() == nil {
+			main.Type = "AAAA"
+		}
+
+		records = append(records, main)
+
+		if zone.WWWInclude {
+			main.Name = "www"
+			records = append(records, main)
+		}
+	}
+
+	records = append(records, zone.Records...)
+
+	slices.SortStableFunc(records, func(a, b api.Record) int {
+		return cmp.Or(
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.Type, b.Type),
+			cmp.Compare(a.Pref, b.Pref),
+			strings.Compare(a.Value, b.Value),
+		)
+	})
+
+	for _, r := range records {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+
+		fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", name, cmp.Or(r.TTL, ttl), r.Type, formatData(r))
+	}
+
+	return b.String()
+}
+
+// formatData renders the record data, domain names are fully qualified.
+func formatData(r api.Record) string {
+	pref := strconv.Itoa(int(r.Pref))
+
+	switch {
+	case slices.Contains(hostTypes, r.Type):
+		return fqdn(r.Value)
+	case r.Type == "MX":
+		return pref + " " + fqdn(r.Value)
+	case r.Type == "SRV" || r.Type == "NAPTR":
+		// The target, or the replacement, is the last field of the value.
+		// The quoted strings of NAPTR values may contain spaces, so the value is split like the zone file is parsed.
+		fields, _, err := tokenize(r.Value)
+		if err != nil || len(fields) == 0 {
+			return pref + " " + r.Value
+		}
+
+		fields[len(fields)-1] = fqdn(fields[len(fields)-1])
+
+		return pref + " " + strings.Join(fields, " ")
+	case r.Type == "TXT" || r.Type == "SPF":
+		return quote(r.Value)
+	default:
+		return r.Value
+	}
+}
+
+// fqdn returns the domain name with a trailing dot.
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+
+	return name + "."
+}
+
+// mailbox returns the email address as the domain name of the SOA record, e.g. hostmaster.example.com.
+// Dots in the local part are escaped.
+func mailbox(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return fqdn(email)
+	}
+
+	return strings.ReplaceAll(local, ".", "\\.") + "." + fqdn(domain)
+}
+
+// serial derives the SOA serial from the time the zone has been updated, AutoDNS doesn't return the serial.
+func serial(updated string) int64 {
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", updated)
+	if err != nil {
+		return 1
+	}
+
+	return t.Unix()
+}
+
+// quote renders the value as one or more quoted character strings.
+func quote(value string) string {
+	chunks := []string{}
+	for len(value) > maxStringLength {
+		chunks = append(chunks, value[:maxStringLength])
+		value = value[maxStringLength:]
+	}
+	chunks = append(chunks, value)
+
+	for i, c := range chunks {
+		chunks[i] = Quote(c)
+	}
+
+	if len(chunks) == 1 {
+		return chunks[0]
+	}
+
+	return "( " + strings.Join(chunks, " ") + " )"
+}
+
+// Quote renders the value as a quoted character string in the presentation format of RFC 1035.
+// Quotes and backslashes are escaped with a backslash, non-printable and non-ASCII bytes as \DDD.
+func Quote(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c > '~':
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package zonefile
+
+import (
+	"reflect"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"testing"
+)
+
+var testZone = &api.Zone{
+	Origin:            "example.com",
+	VirtualNameServer: "a.ns14.net",
+	SOA:               &api.SOA{Refresh: 43200, Retry: 7200, Expire: 1209600, TTL: 86400, Email: "host.master@example.com", Minimum: 300},
+	NameServers:       []api.NameServer{{Name: "a.ns14.net"}, {Name: "b.ns14.net", TTL: 3600}},
+	Main:              &api.MainIP{Address: "2001:db8::1"},
+	WWWInclude:        true,
+	Records: []api.Record{
+		{Name: "", Type: "MX", TTL: 300, Value: "mx2.example.com", Pref: 20},
+		{Name: "", Type: "MX", TTL: 300, Value: "mx1.example.com", Pref: 10},
+		{Name: "_sip._tcp", Type: "SRV", TTL: 600, Value: "60 5060 sip.example.com", Pref: 10},
+		{Name: "long", Type: "TXT", TTL: 60, Value: strings.Repeat("a", 300)},
+		{Name: "txt", Type: "TXT", TTL: 60, Value: `say "hi"`},
+		{Name: "naptr", Type: "NAPTR", TTL: 60, Value: `10 "U" "E2U+sip" "!^(.*)  (.*)$!sip:\\2@example.com!" sip.example.com`, Pref: 100},
+	},
+	Updated: "2024-01-01T00:00:00.000+0000",
+}
+
+var testFormattedZone = `$ORIGIN example.com.
+$TTL 86400
+@	86400	IN	SOA	a.ns14.net. host\.master.example.com. 1704067200 43200 7200 1209600 300
+@	86400	IN	AAAA	2001:db8::1
+@	300	IN	MX	10 mx1.example.com.
+@	300	IN	MX	20 mx2.example.com.
+@	86400	IN	NS	a.ns14.net.
+@	3600	IN	NS	b.ns14.net.
+_sip._tcp	600	IN	SRV	10 60 5060 sip.example.com.
+long	60	IN	TXT	( "` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `" )
+naptr	60	IN	NAPTR	100 10 "U" "E2U+sip" "!^(.*)  (.*)$!sip:\\2@example.com!" sip.example.com.
+txt	60	IN	TXT	"say \"hi\""
+www	86400	IN	AAAA	2001:db8::1
+`
+
+func TestFormat(t *testing.T) {
+	if got := Format(testZone); got != testFormattedZone {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, testFormattedZone)
+	}
+}
+
+func TestFormatDefaultMinimum(t *testing.T) {
+	zone := &api.Zone{Origin: "example.com", VirtualNameServer: "a.ns14.net", SOA: &api.SOA{TTL: 600}}
+
+	soa := strings.Split(Format(zone), "\n")[2]
+	if want := " 0 0 0 3600"; !strings.HasSuffix(soa, want) {
+		t.Errorf("Format() rendered the SOA record %q, want the default minimum instead of the $TTL", soa)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: `""`},
+		{in: "v=spf1 -all", want: `"v=spf1 -all"`},
+		{in: `say "hi" \o/`, want: `"say \"hi\" \\o/"`},
+		{in: "s\u00fcd\t\x7f", want: `"s\195\188d\009\127"`},
+	}
+
+	for _, tt := range tests {
+		got := Quote(tt.in)
+		if got != tt.want {
+			t.Errorf("Quote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+
+		if v, err := Unquote(got); v != tt.in || err != nil {
+			t.Errorf("Unquote(Quote(%q)) = %q, %v", tt.in, v, err)
+		}
+	}
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	records, err := Parse(strings.NewReader(Format(testZone)), testZone.Origin)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Drop the records which are settings of the zone
+	filtered := []api.Record{}
+	for _, r := range records {
+		if r.Type != "NS" && r.Type != "AAAA" {
+			filtered = append(filtered, r)
+		}
+	}
+
+	want := []api.Record{testZone.Records[1], testZone.Records[0], testZone.Records[2], testZone.Records[3], testZone.Records[5], testZone.Records[4]}
+	if !reflect.DeepEqual(filtered, want) {
+		t.Errorf("Parse(Format()) =\n%+v\nwant\n%+v", filtered, want)
+	}
+}
@@ -0,0 +1,452 @@
+// Package zonefile converts between RFC 1035 master files, also known as BIND zone files, and AutoDNS records.
+package zonefile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"unicode"
+)
+
+// hostTypes are the record types whose value is a single domain name.
+var hostTypes = []string{"CNAME", "NS", "PTR", "DNAME"}
+
+// classes are the record classes of RFC 1035, only records of class IN are supported.
+var classes = []string{"IN", "CH", "HS", "CS"}
+
+// parser holds the state carried from one entry of the zone file to the next.
+type parser struct {
+	zone   string
+	origin string
+	name   string
+
+	// ttl is the default TTL set by $TTL.
+	ttl    int64
+	hasTTL bool
+	// last is the TTL of the previous record, used by files without $TTL.
+	last    int64
+	hasLast bool
+}
+
+// Parse reads the zone file of the zone with the origin and returns its records.
+// Names and domain name values are made relative to the origin, like AutoDNS stores them.
+// The SOA record is skipped, as AutoDNS manages it as part of the zone.
+func Parse(r io.Reader, origin string) ([]api.Record, error) {
+	p := &parser{
+		zone:   canonical(origin),
+		origin: canonical(origin),
+	}
+
+	records := []api.Record{}
+
+	entries, err := readEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		record, ok, err := p.parseEntry(e)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.line, err)
+		}
+
+		if ok {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// entry is a logical line of the zone file, entries in parentheses may span multiple lines.
+type entry struct {
+	line int
+	// blank is set when the entry starts with whitespace, it belongs to the previous name then.
+	blank  bool
+	tokens []string
+}
+
+// readEntries splits the zone file into entries, dropping comments and empty lines.
+func readEntries(r io.Reader) ([]entry, error) {
+	entries := []entry{}
+	scanner := bufio.NewScanner(r)
+
+	var current *entry
+	depth := 0
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+
+		if current == nil {
+			current = &entry{line: n, blank: line != "" && unicode.IsSpace(rune(line[0]))}
+		}
+
+		tokens, d, err := tokenize(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		current.tokens = append(current.tokens, tokens...)
+		depth += d
+
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", n)
+		}
+
+		if depth == 0 {
+			if len(current.tokens) != 0 {
+				entries = append(entries, *current)
+			}
+
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.line)
+	}
+
+	return entries, nil
+}
+
+// tokenize splits a line into tokens, quoted strings are kept including their quotes.
+// It returns how the line changes the depth of parentheses.
+func tokenize(line string) ([]string, int, error) {
+	tokens := []string{}
+	depth := 0
+
+	var token strings.Builder
+	flush := func() {
+		if token.Len() != 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case c == ';':
+			flush()
+			return tokens, depth, nil
+		case c == '(' || c == ')':
+			flush()
+			if c == '(' {
+				depth++
+			} else {
+				depth--
+			}
+		case c == '"':
+			flush()
+
+			end := i + 1
+			for ; end < len(line) && line[end] != '"'; end++ {
+				if line[end] == '\\' {
+					end++
+				}
+			}
+
+			if end >= len(line) {
+				return nil, 0, fmt.Errorf("unterminated quoted string")
+			}
+
+			tokens = append(tokens, line[i:end+1])
+			i = end
+		case c == '\\' && i+1 < len(line):
+			token.WriteByte(c)
+			token.WriteByte(line[i+1])
+			i++
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			token.WriteByte(c)
+		}
+	}
+
+	flush()
+
+	return tokens, depth, nil
+}
+
+// parseEntry handles a control entry or returns the record of the entry.
+// ok is false for entries which don't result in a record.
+func (p *parser) parseEntry(e entry) (record api.Record, ok bool, err error) {
+	tokens := e.tokens
+
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return record, false, fmt.Errorf("$ORIGIN requires a domain name")
+		}
+
+		p.origin = p.absolute(tokens[1])
+
+		return record, false, nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return record, false, fmt.Errorf("$TTL requires a TTL")
+		}
+
+		ttl, ok := parseTTL(tokens[1])
+		if !ok {
+			return record, false, fmt.Errorf("invalid TTL: %s", tokens[1])
+		}
+
+		p.ttl, p.hasTTL = ttl, true
+
+		return record, false, nil
+	case "$INCLUDE", "$GENERATE":
+		return record, false, fmt.Errorf("%s is not supported", tokens[0])
+	}
+
+	// The name is omitted when the entry starts with whitespace
+	if !e.blank {
+		p.name = p.absolute(tokens[0])
+		tokens = tokens[1:]
+	}
+
+	if p.name == "" {
+		return record, false, fmt.Errorf("the record has no name")
+	}
+
+	// TTL and class may appear in any order before the type
+	var ttl int64
+	hasTTL := false
+	for len(tokens) != 0 {
+		if slices.Contains(classes, strings.ToUpper(tokens[0])) {
+			if strings.ToUpper(tokens[0]) != "IN" {
+				return record, false, fmt.Errorf("unsupported class: %s", tokens[0])
+			}
+
+			tokens = tokens[1:]
+			continue
+		}
+
+		if t, ok := parseTTL(tokens[0]); ok {
+			ttl, hasTTL = t, true
+			tokens = tokens[1:]
+			continue
+		}
+
+		break
+	}
+
+	if len(tokens) < 2 {
+		return record, false, fmt.Errorf("the record has no type or data")
+	}
+
+	// Without $TTL, the TTL of the previous record is used
+	switch {
+	case hasTTL:
+	case p.hasTTL:
+		ttl = p.ttl
+	case p.hasLast:
+		ttl = p.last
+	default:
+		return record, false, fmt.Errorf("the record has no TTL and no $TTL has been set")
+	}
+
+	p.last, p.hasLast = ttl, true
+
+	recordType := strings.ToUpper(tokens[0])
+	data := tokens[1:]
+
+	if recordType == "SOA" {
+		return record, false, nil
+	}
+
+	name, err := p.relative(p.name)
+	if err != nil {
+		return record, false, err
+	}
+
+	record = api.Record{Name: name, Type: recordType, TTL: ttl}
+
+	switch {
+	case slices.Contains(hostTypes, recordType):
+		if len(data) != 1 {
+			return record, false, fmt.Errorf("%s record requires a single domain name", recordType)
+		}
+
+		record.Value = p.host(data[0])
+	case recordType == "MX":
+		if len(data) != 2 {
+			return record, false, fmt.Errorf("MX record requires a preference and an exchange")
+		}
+
+		record.Pref, err = parsePref(data[0])
+		record.Value = p.host(data[1])
+	case recordType == "SRV":
+		if len(data) != 4 {
+			return record, false, fmt.Errorf("SRV record requires a priority, weight, port and target")
+		}
+
+		record.Pref, err = parsePref(data[0])
+		record.Value = strings.Join([]string{data[1], data[2], p.host(data[3])}, " ")
+	case recordType == "NAPTR":
+		if len(data) != 6 {
+			return record, false, fmt.Errorf("NAPTR record requires an order, preference, flags, service, regexp and replacement")
+		}
+
+		record.Pref, err = parsePref(data[0])
+		record.Value = strings.Join(slices.Concat(data[1:5], []string{p.host(data[5])}), " ")
+	case recordType == "TXT" || recordType == "SPF":
+		// Long values are split into multiple strings, AutoDNS stores them as one value
+		var value strings.Builder
+		for _, s := range data {
+			v, err := unquote(s)
+			if err != nil {
+				return record, false, err
+			}
+
+			value.WriteString(v)
+		}
+
+		record.Value = value.String()
+	default:
+		record.Value = strings.Join(data, " ")
+	}
+
+	return record, err == nil, err
+}
+
+// absolute qualifies a name of the zone file with the current origin.
+func (p *parser) absolute(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return canonical(name)
+	case p.origin == "":
+		return canonical(name)
+	default:
+		return canonical(name) + "." + p.origin
+	}
+}
+
+// relative returns the name relative to the zone, the zone apex is the empty name.
+func (p *parser) relative(name string) (string, error) {
+	switch {
+	case strings.EqualFold(name, p.zone):
+		return "", nil
+	case strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(p.zone)):
+		return name[:len(name)-len(p.zone)-1], nil
+	default:
+		return "", fmt.Errorf("%s is not part of the zone %s", name, p.zone)
+	}
+}
+
+// host returns a domain name value, fully qualified without the trailing dot.
+func (p *parser) host(name string) string {
+	if name == "." {
+		return name
+	}
+
+	return p.absolute(name)
+}
+
+// canonical removes the trailing dot of a domain name.
+func canonical(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+// parseTTL parses a TTL in seconds, or with the BIND units like 1h30m.
+func parseTTL(s string) (int64, bool) {
+	if ttl, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ttl, ttl >= 0
+	}
+
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+
+	var ttl, n int64
+	digits := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int64(c-'0')
+			digits = true
+		case units[c|0x20] != 0 && digits:
+			ttl += n * units[c|0x20]
+			n, digits = 0, false
+		default:
+			return 0, false
+		}
+	}
+
+	// A unit is required after every number, otherwise it's not a TTL but e.g. a name
+	if digits || s == "" {
+		return 0, false
+	}
+
+	return ttl, true
+}
+
+func parsePref(s string) (int32, error) {
+	pref, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid preference: %s", s)
+	}
+
+	return int32(pref), nil
+}
+
+// unquote removes the quotes of a character string and resolves its escapes, unquoted strings are returned as is.
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' {
+		return s, nil
+	}
+
+	return Unquote(s)
+}
+
+// Unquote removes the quotes of a character string in the presentation format of RFC 1035 and resolves its escapes.
+// A backslash either escapes the following character or starts a \DDD escape of a byte value.
+func Unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("character string %s must be quoted", s)
+	}
+
+	s = s[1 : len(s)-1]
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '"':
+			return "", fmt.Errorf("unescaped quote in character string %q", s)
+		case s[i] != '\\':
+			b.WriteByte(s[i])
+		case i+1 == len(s):
+			return "", fmt.Errorf("character string %q ends with a backslash", s)
+		case i+3 < len(s) && isDigits(s[i+1:i+4]):
+			n, _ := strconv.Atoi(s[i+1 : i+4])
+			if n > 255 {
+				return "", fmt.Errorf("escape \\%s in character string %q exceeds a byte", s[i+1:i+4], s)
+			}
+
+			b.WriteByte(byte(n))
+			i += 3
+		default:
+			i++
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// isDigits reports whether s only consists of decimal digits.
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
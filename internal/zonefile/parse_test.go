@@ -0,0 +1,125 @@
+package zonefile
+
+import (
+	"reflect"
+	"strings"
+	"terraform-provider-autodns/internal/api"
+	"testing"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	a.ns14.net. hostmaster.example.com. (
+		2024010101 ; serial
+		43200      ; refresh
+		7200       ; retry
+		1209600    ; expire
+		86400 )    ; minimum
+	IN	NS	a.ns14.net.
+	IN	MX	10 mx1
+	IN	MX	20 mx2.example.net.
+@	300	IN	A	192.0.2.1
+www	CNAME	@
+_sip._tcp	IN	600	SRV	10 60 5060 sip
+	NAPTR	100 10 "S" "SIP+D2U" "" _sip._udp
+txt	TXT	"v=spf1 include:example.net"
+	TXT	( "first part "
+		  "second \"part\"" )
+caa	CAA	0 issue "letsencrypt.org"
+$ORIGIN sub.example.com.
+host	1d	AAAA	2001:db8::1
+`
+
+func TestParse(t *testing.T) {
+	records, err := Parse(strings.NewReader(testZoneFile), "example.com")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []api.Record{
+		{Name: "", Type: "NS", TTL: 3600, Value: "a.ns14.net"},
+		{Name: "", Type: "MX", TTL: 3600, Value: "mx1.example.com", Pref: 10},
+		{Name: "", Type: "MX", TTL: 3600, Value: "mx2.example.net", Pref: 20},
+		{Name: "", Type: "A", TTL: 300, Value: "192.0.2.1"},
+		{Name: "www", Type: "CNAME", TTL: 3600, Value: "example.com"},
+		{Name: "_sip._tcp", Type: "SRV", TTL: 600, Value: "60 5060 sip.example.com", Pref: 10},
+		{Name: "_sip._tcp", Type: "NAPTR", TTL: 3600, Value: `10 "S" "SIP+D2U" "" _sip._udp.example.com`, Pref: 100},
+		{Name: "txt", Type: "TXT", TTL: 3600, Value: "v=spf1 include:example.net"},
+		{Name: "txt", Type: "TXT", TTL: 3600, Value: `first part second "part"`},
+		{Name: "caa", Type: "CAA", TTL: 3600, Value: `0 issue "letsencrypt.org"`},
+		{Name: "host.sub", Type: "AAAA", TTL: 86400, Value: "2001:db8::1"},
+	}
+
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", records, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "no ttl", in: "www IN A 192.0.2.1\n"},
+		{name: "out of zone", in: "$TTL 60\nwww.example.net. IN A 192.0.2.1\n"},
+		{name: "unbalanced parentheses", in: "$TTL 60\nwww IN TXT ( \"a\"\n"},
+		{name: "unterminated string", in: "$TTL 60\nwww IN TXT \"a\n"},
+		{name: "other class", in: "$TTL 60\nwww CH A 192.0.2.1\n"},
+		{name: "include", in: "$INCLUDE other.zone\n"},
+		{name: "invalid pref", in: "$TTL 60\n@ MX high mx1\n"},
+		{name: "escape exceeding a byte", in: "$TTL 60\nwww IN TXT \"\\256\"\n"},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(strings.NewReader(tt.in), "example.com"); err == nil {
+			t.Errorf("Parse() of %s succeeded, want an error", tt.name)
+		}
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{in: "3600", want: 3600, ok: true},
+		{in: "1h30m", want: 5400, ok: true},
+		{in: "1W", want: 604800, ok: true},
+		{in: "1h30", ok: false},
+		{in: "www", ok: false},
+		{in: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseTTL(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseTTL(%q) = %d, %v, want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{in: `""`, want: "", ok: true},
+		{in: `"say \"hi\""`, want: `say "hi"`, ok: true},
+		{in: `"a\\b\.c"`, want: `a\b.c`, ok: true},
+		{in: `"s\195\188d\009"`, want: "s\u00fcd\t", ok: true},
+		{in: `"\0651"`, want: "A1", ok: true},
+		{in: `"\256"`, ok: false},
+		{in: `"a\"`, ok: false},
+		{in: `"a"b"`, ok: false},
+		{in: `unquoted`, ok: false},
+	}
+
+	for _, tt := range tests {
+		got, err := Unquote(tt.in)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("Unquote(%s) = %q, %v, want %q, ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"terraform-provider-autodns/cmd/generate"
 	"terraform-provider-autodns/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -16,6 +18,11 @@ var (
 )
 
 func main() {
+	// Terraform starts the provider without arguments, subcommands are meant to be run by users
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate.Run(context.Background(), os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -27,6 +34,15 @@ func main() {
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Sessions must not outlive the provider process, the logout has to finish before Terraform kills it
+	ctx, cancel := context.WithTimeout(context.Background(), provider.ShutdownTimeout)
+	defer cancel()
+
+	if shutdownErr := provider.Shutdown(ctx); shutdownErr != nil {
+		log.Printf("unable to log out of AutoDNS: %s", shutdownErr)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}